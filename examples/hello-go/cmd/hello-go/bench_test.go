@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+// BenchmarkNDJSON compares writing each result as it is produced, as the
+// json output does, with collecting all results before encoding them, and
+// with creating an encoder per line.
+func BenchmarkNDJSON(b *testing.B) {
+	g, err := greet.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+	names := make([]string, 1000)
+	for i := range names {
+		names[i] = fmt.Sprintf("Guest %d", i)
+	}
+
+	b.Run("streaming", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			out := newOutput(io.Discard, formatJSON, 1, false)
+			for _, name := range names {
+				if err := out.write(g.Result(name)); err != nil {
+					b.Fatal(err)
+				}
+			}
+			if err := out.close(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("collect", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			var results []greet.Result
+			for _, name := range names {
+				results = append(results, g.Result(name))
+			}
+			enc := json.NewEncoder(io.Discard)
+			for _, r := range results {
+				if err := enc.Encode(r); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("encoder-per-line", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			for _, name := range names {
+				if err := json.NewEncoder(io.Discard).Encode(g.Result(name)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,21 @@
+//go:build !plan9
+
+package main
+
+import (
+	"errors"
+	"os/signal"
+	"syscall"
+)
+
+// ignoreSIGPIPE makes writes to a closed pipe fail with EPIPE instead of
+// killing the process with SIGPIPE, so that checkWrite sees them.
+func ignoreSIGPIPE() {
+	signal.Ignore(syscall.SIGPIPE)
+}
+
+// isBrokenPipe reports whether err is the EPIPE of a write whose reader has
+// gone away.
+func isBrokenPipe(err error) bool {
+	return errors.Is(err, syscall.EPIPE)
+}
@@ -0,0 +1,12 @@
+//go:build plan9
+
+package main
+
+// ignoreSIGPIPE does nothing: Plan 9 has no SIGPIPE.
+func ignoreSIGPIPE() {}
+
+// isBrokenPipe reports false: Plan 9 has no EPIPE, so only io.ErrClosedPipe
+// is recognized by checkWrite.
+func isBrokenPipe(err error) bool {
+	return false
+}
@@ -0,0 +1,57 @@
+//go:build !plan9
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// brokenPipeWriter accepts its first accept writes and fails all later ones
+// with EPIPE, like stdout piped into a head that has exited.
+type brokenPipeWriter struct {
+	accept, writes int
+}
+
+func (w *brokenPipeWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.writes > w.accept {
+		return 0, &os.PathError{Op: "write", Path: "/dev/stdout", Err: syscall.EPIPE}
+	}
+	return len(p), nil
+}
+
+func TestRunBrokenPipe(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  io.Reader
+		accept int
+	}{
+		{"repeat", []string{"-repeat=1000000", "Sam"}, nil, 1},
+		{"stream", []string{"-format=json"}, strings.NewReader(strings.Repeat("Sam\n", 100000)), 1},
+		{"version", []string{"version"}, nil, 0},
+		{"completion", []string{"completion", "bash"}, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &brokenPipeWriter{accept: tt.accept}
+			var stderr bytes.Buffer
+			args := append([]string{"hello-go"}, tt.args...)
+			if code := run(context.Background(), args, tt.stdin, w, &stderr, noEnv, nil, nil); code != exitOK {
+				t.Errorf("exit code = %d, want %d", code, exitOK)
+			}
+			if stderr.Len() != 0 {
+				t.Errorf("stderr = %q, want empty", stderr.String())
+			}
+			if w.writes > tt.accept+1 {
+				t.Errorf("%d writes after the pipe broke, want the run to stop", w.writes-tt.accept)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+// Check validates a config and the templates of a locale file together. It
+// returns every problem it finds: a config lang that is neither built in nor
+// in locales, an unknown config greeting style and templates that fail
+// greet.ValidateTemplate.
+func Check(cfg Config, locales map[greet.Locale]string) []error {
+	errs := checkTemplates(locales)
+	if l := greet.Locale(cfg.Lang); l != "" && !l.Supported() {
+		if _, ok := locales[l]; !ok {
+			errs = append(errs, fmt.Errorf("config: lang: %w", greet.LocaleError{Locale: l}))
+		}
+	}
+	switch cfg.Greeting {
+	case "", styleHello, styleTimeOfDay:
+	default:
+		errs = append(errs, fmt.Errorf("config: unknown greeting %q (want hello or timeofday)", cfg.Greeting))
+	}
+	return errs
+}
+
+// checkCommand implements the check subcommand: it validates the config file
+// and locale file and reports each problem on stderr without greeting.
+func checkCommand(args []string, stderr io.Writer, env func(string) string) error {
+	fs := flag.NewFlagSet("hello-go check", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configPath := fs.String("config", defaultConfigPath(env), "path to the TOML config file")
+	localeFile := fs.String("locale-file", "", "JSON `file` of extra greeting templates")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return &exitError{code: exitUsage}
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("check: unexpected arguments %q", fs.Args())
+	}
+
+	var problems []error
+	var cfg Config
+	if *configPath != "" {
+		var err error
+		if cfg, err = LoadConfig(*configPath); err != nil {
+			problems = append(problems, err)
+		} else if configSet(fs) && !exists(*configPath) {
+			problems = append(problems, fmt.Errorf("config file %s does not exist", *configPath))
+		}
+	}
+	var locales map[greet.Locale]string
+	if *localeFile != "" {
+		var err error
+		if locales, err = readLocaleFile(*localeFile); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	problems = append(problems, Check(cfg, locales)...)
+	for _, p := range problems {
+		fmt.Fprintf(stderr, "hello-go check: %v\n", p)
+	}
+	if len(problems) > 0 {
+		return &exitError{code: exitFailure}
+	}
+	return nil
+}
+
+// configSet reports whether -config was given explicitly to fs.
+func configSet(fs *flag.FlagSet) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) { set = set || f.Name == "config" })
+	return set
+}
+
+// exists reports whether a file exists at path.
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, fs.ErrNotExist)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+func TestCheckValid(t *testing.T) {
+	tests := []struct {
+		cfg     Config
+		locales map[greet.Locale]string
+	}{
+		{Config{}, nil},
+		{Config{Name: "Marie", Lang: "fr", Greeting: "timeofday"}, nil},
+		{Config{Lang: "pt"}, map[greet.Locale]string{"pt": "Olá, %s! %s"}},
+	}
+	for _, tt := range tests {
+		if errs := Check(tt.cfg, tt.locales); len(errs) != 0 {
+			t.Errorf("Check(%+v, %q) = %v, want no problems", tt.cfg, tt.locales, errs)
+		}
+	}
+}
+
+func TestCheckProblems(t *testing.T) {
+	errs := Check(Config{Lang: "pt"}, map[greet.Locale]string{"eo": "Saluton!"})
+	if len(errs) != 2 {
+		t.Fatalf("Check returned %d problems %v, want 2", len(errs), errs)
+	}
+	if !strings.Contains(errs[0].Error(), `"eo"`) || !strings.Contains(errs[0].Error(), "placeholder") {
+		t.Errorf("problem 0 = %v, want missing placeholder in eo", errs[0])
+	}
+	if !errors.Is(errs[1], greet.ErrUnsupportedLocale) {
+		t.Errorf("problem 1 = %v, want unsupported locale", errs[1])
+	}
+}
+
+func TestRunCheck(t *testing.T) {
+	goodConfig := writeConfig(t, "lang = \"pt\"\n")
+	goodLocales := writeLocaleFile(t, `{"pt": "Olá, %s! %s"}`)
+	badConfig := writeConfig(t, "lang = \"eo\"\ngreeting = \"howdy\"\n")
+	badLocales := writeLocaleFile(t, `{"pt": "Olá!"}`)
+	tests := []struct {
+		name     string
+		args     []string
+		code     int
+		problems []string
+	}{
+		{"valid", []string{"-config=" + goodConfig, "-locale-file=" + goodLocales}, exitOK, nil},
+		{"invalid", []string{"-config=" + badConfig, "-locale-file=" + badLocales}, exitFailure,
+			[]string{`locale "pt"`, `unsupported locale "eo"`, `unknown greeting "howdy"`}},
+		{"unknown locale without file", []string{"-config=" + goodConfig}, exitFailure, []string{`unsupported locale "pt"`}},
+		{"missing files", []string{"-config=" + filepath.Join(t.TempDir(), "none.toml"), "-locale-file=" + filepath.Join(t.TempDir(), "none.json")},
+			exitFailure, []string{"config file", "loading locale file"}},
+		{"arguments", []string{"Sam"}, exitUsage, []string{"unexpected arguments"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"hello-go", "check"}, tt.args...)
+			if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, nil, nil); code != tt.code {
+				t.Errorf("exit code = %d, want %d (stderr %q)", code, tt.code, stderr.String())
+			}
+			if stdout.Len() != 0 {
+				t.Errorf("stdout = %q, want empty", stdout.String())
+			}
+			for _, p := range tt.problems {
+				if !strings.Contains(stderr.String(), p) {
+					t.Errorf("stderr = %q, want problem %q", stderr.String(), p)
+				}
+			}
+			if tt.problems == nil && stderr.Len() != 0 {
+				t.Errorf("stderr = %q, want empty", stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"text/template"
+)
+
+// completionData holds the words substituted into the completion scripts.
+type completionData struct {
+	Locales   string
+	Formats   string
+	Greetings string
+	Colors    string
+	Commands  string
+	Shells    string
+}
+
+var completionScripts = map[string]*template.Template{
+	"bash": template.Must(template.New("bash").Parse(`# bash completion for hello-go
+_hello_go() {
+    local cur prev
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+
+    case "$prev" in
+        -lang|--lang) COMPREPLY=($(compgen -W "{{.Locales}}" -- "$cur")); return ;;
+        -format|--format) COMPREPLY=($(compgen -W "{{.Formats}}" -- "$cur")); return ;;
+        -greeting|--greeting) COMPREPLY=($(compgen -W "{{.Greetings}}" -- "$cur")); return ;;
+        -color|--color) COMPREPLY=($(compgen -W "{{.Colors}}" -- "$cur")); return ;;
+        -config|--config) COMPREPLY=($(compgen -f -- "$cur")); return ;;
+        completion) COMPREPLY=($(compgen -W "{{.Shells}}" -- "$cur")); return ;;
+    esac
+
+    if [[ "$cur" == -* ]]; then
+        COMPREPLY=($(compgen -W "--lang --format --greeting --color --config" -- "$cur"))
+    elif [[ $COMP_CWORD -eq 1 ]]; then
+        COMPREPLY=($(compgen -W "{{.Commands}}" -- "$cur"))
+    fi
+}
+complete -F _hello_go hello-go
+`)),
+	"zsh": template.Must(template.New("zsh").Parse(`#compdef hello-go
+
+_hello_go() {
+    local state
+    _arguments -C \
+        '--lang=[greeting language]:language:({{.Locales}})' \
+        '--format=[output format]:format:({{.Formats}})' \
+        '--greeting=[greeting style]:style:({{.Greetings}})' \
+        '--color=[colorize names]:mode:({{.Colors}})' \
+        '--config=[path to the TOML config file]:file:_files' \
+        '1: :->first' \
+        '*:: :->rest'
+
+    case $state in
+        first) _values 'command' {{.Commands}} ;;
+        rest) [[ $words[1] == completion ]] && _values 'shell' {{.Shells}} ;;
+    esac
+}
+
+_hello_go "$@"
+`)),
+	"fish": template.Must(template.New("fish").Parse(`# fish completion for hello-go
+complete -c hello-go -f
+complete -c hello-go -n '__fish_use_subcommand' -a '{{.Commands}}'
+complete -c hello-go -n '__fish_seen_subcommand_from completion' -a '{{.Shells}}'
+complete -c hello-go -l lang -x -a '{{.Locales}}' -d 'Greeting language'
+complete -c hello-go -l format -x -a '{{.Formats}}' -d 'Output format'
+complete -c hello-go -l greeting -x -a '{{.Greetings}}' -d 'Greeting style'
+complete -c hello-go -l color -x -a '{{.Colors}}' -d 'Colorize names'
+complete -c hello-go -l config -r -F -d 'Path to the TOML config file'
+`)),
+}
+
+// completionShells lists the shells GenerateCompletion supports.
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// GenerateCompletion writes the completion script for shell (bash, zsh or
+// fish) to w.
+func GenerateCompletion(shell string, w io.Writer) error {
+	tmpl, ok := completionScripts[shell]
+	if !ok {
+		return usageErrorf("unsupported shell %q (want %s)", shell, strings.Join(completionShells, ", "))
+	}
+	var b strings.Builder
+	err := tmpl.Execute(&b, completionData{
+		Locales:   joinLocales(" "),
+		Formats:   strings.Join([]string{formatText, formatJSON, formatCSV}, " "),
+		Greetings: strings.Join([]string{styleHello, styleTimeOfDay}, " "),
+		Colors:    strings.Join([]string{colorAuto, colorAlways, colorNever}, " "),
+		Commands:  "version completion serve repl check locales",
+		Shells:    strings.Join(completionShells, " "),
+	})
+	if err != nil {
+		return err
+	}
+	return writeAll(w, b.String())
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestGenerateCompletionBash(t *testing.T) {
+	var buf bytes.Buffer
+	if err := GenerateCompletion("bash", &buf); err != nil {
+		t.Fatalf("GenerateCompletion error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "complete -F _hello_go hello-go") {
+		t.Errorf("bash completion lacks complete -F line:\n%s", out)
+	}
+	if !strings.Contains(out, `compgen -W "ar de en es fr he ja"`) {
+		t.Errorf("bash completion does not list the languages:\n%s", out)
+	}
+	for _, cmd := range []string{"version", "completion"} {
+		if !strings.Contains(out, cmd) {
+			t.Errorf("bash completion does not mention %q", cmd)
+		}
+	}
+}
+
+func TestGenerateCompletionShells(t *testing.T) {
+	for _, shell := range completionShells {
+		var buf bytes.Buffer
+		if err := GenerateCompletion(shell, &buf); err != nil {
+			t.Errorf("GenerateCompletion(%q) error: %v", shell, err)
+		}
+		if !strings.Contains(buf.String(), "ar de en es fr he ja") {
+			t.Errorf("%s completion does not list the languages", shell)
+		}
+		if !strings.Contains(buf.String(), "version completion serve repl check locales") {
+			t.Errorf("%s completion does not list the subcommands", shell)
+		}
+	}
+}
+
+func TestRunCompletionUnsupportedShell(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run(context.Background(), []string{"hello-go", "completion", "tcsh"}, nil, &stdout, &stderr, noEnv, nil, nil); code == 0 {
+		t.Error("exit code = 0, want failure")
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want empty", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), `unsupported shell "tcsh"`) {
+		t.Errorf("stderr = %q, want unsupported shell message", stderr.String())
+	}
+}
+
+func TestRunCompletion(t *testing.T) {
+	var stdout bytes.Buffer
+	if code := run(context.Background(), []string{"hello-go", "completion", "fish"}, nil, &stdout, io.Discard, noEnv, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0", code)
+	}
+	if !strings.Contains(stdout.String(), "complete -c hello-go") {
+		t.Errorf("stdout = %q, want fish completion", stdout.String())
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+// GreetConcurrent returns the greeting built by g for each of names, in the
+// order of names, computing them on n goroutines. An n of zero or less uses
+// runtime.NumCPU goroutines. It returns ctx.Err() if ctx is canceled before
+// all names have been handed out.
+func GreetConcurrent(ctx context.Context, names []string, n int, g *greet.Greeter) ([]string, error) {
+	results, err := resultsConcurrent(ctx, names, n, g)
+	if err != nil {
+		return nil, err
+	}
+	greetings := make([]string, len(results))
+	for i, r := range results {
+		greetings[i] = r.Greeting
+	}
+	return greetings, nil
+}
+
+// resultsConcurrent is GreetConcurrent returning full Results. Workers take
+// indexes from a shared channel and store each Result at its input's index,
+// so the output order does not depend on scheduling.
+func resultsConcurrent(ctx context.Context, names []string, n int, g *greet.Greeter) ([]greet.Result, error) {
+	if n <= 0 {
+		n = runtime.NumCPU()
+	}
+	n = min(n, len(names))
+	results := make([]greet.Result, len(names))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = g.Result(names[i])
+			}
+		}()
+	}
+
+	var err error
+feed:
+	for i := range names {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
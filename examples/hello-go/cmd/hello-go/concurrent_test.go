@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+func TestGreetConcurrent(t *testing.T) {
+	g := newGreeter(t, greet.WithLocale(greet.French), greet.WithTitleCase(true))
+	names := make([]string, 1000)
+	want := make([]string, len(names))
+	for i := range names {
+		names[i] = fmt.Sprintf("name-%04d", i)
+		want[i] = g.Greet(names[i])
+	}
+	for _, n := range []int{8, 1, 0, -1} {
+		got, err := GreetConcurrent(context.Background(), names, n, g)
+		if err != nil {
+			t.Fatalf("n=%d: GreetConcurrent error: %v", n, err)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("n=%d: concurrent output differs from sequential output", n)
+		}
+	}
+}
+
+func TestGreetConcurrentEmpty(t *testing.T) {
+	got, err := GreetConcurrent(context.Background(), nil, 4, newGreeter(t))
+	if err != nil || len(got) != 0 {
+		t.Errorf("GreetConcurrent(nil) = %q, %v; want empty", got, err)
+	}
+}
+
+func TestGreetConcurrentCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := GreetConcurrent(ctx, []string{"a", "b", "c"}, 2, newGreeter(t)); !errors.Is(err, context.Canceled) {
+		t.Errorf("GreetConcurrent error = %v, want context.Canceled", err)
+	}
+}
+
+func TestRunConcurrency(t *testing.T) {
+	var lines []string
+	for i := range 200 {
+		lines = append(lines, fmt.Sprintf("Guest %d", i))
+	}
+	stdin := strings.Join(lines, "\n")
+	var sequential, concurrent bytes.Buffer
+	if code := run(context.Background(), []string{"hello-go", "-format=json"}, strings.NewReader(stdin), &sequential, io.Discard, noEnv, nil, nil); code != 0 {
+		t.Fatalf("sequential: exit code = %d", code)
+	}
+	if code := run(context.Background(), []string{"hello-go", "-format=json", "-concurrency=8"}, strings.NewReader(stdin), &concurrent, io.Discard, noEnv, nil, nil); code != 0 {
+		t.Fatalf("concurrent: exit code = %d", code)
+	}
+	if sequential.String() != concurrent.String() {
+		t.Error("-concurrency=8 output differs from sequential output")
+	}
+}
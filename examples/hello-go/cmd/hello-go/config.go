@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Config holds the defaults read from the config file. Empty fields are
+// unset and leave the built-in defaults in place.
+type Config struct {
+	Name     string `toml:"name"`
+	Lang     string `toml:"lang"`
+	Greeting string `toml:"greeting"`
+	Word     string `toml:"word"`
+}
+
+// A ConfigError reports a config file that could not be loaded.
+type ConfigError struct {
+	Path string
+	Err  error
+}
+
+func (e ConfigError) Error() string {
+	return "loading config " + e.Path + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// LoadConfig reads the TOML config file at path. A missing file is not an
+// error and yields the zero Config; other failures are reported as a
+// ConfigError.
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+	md, err := toml.DecodeFile(path, &cfg)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return Config{}, ConfigError{path, err}
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, len(undecoded))
+		for i, k := range undecoded {
+			keys[i] = k.String()
+		}
+		return Config{}, ConfigError{path, fmt.Errorf("unknown keys: %s", strings.Join(keys, ", "))}
+	}
+	return cfg, nil
+}
+
+// defaultConfigPath returns $XDG_CONFIG_HOME/hello-go/config.toml, falling
+// back to $HOME/.config when XDG_CONFIG_HOME is unset. It returns "" if
+// neither variable is set in env.
+func defaultConfigPath(env func(string) string) string {
+	dir := env("XDG_CONFIG_HOME")
+	if dir == "" {
+		home := env("HOME")
+		if home == "" {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "hello-go", "config.toml")
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// noEnv is an empty environment, keeping the developer's own config file
+// and variables out of the tests.
+func noEnv(string) string { return "" }
+
+// fakeEnv returns an environment holding only the given variables.
+func fakeEnv(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := writeConfig(t, "name = \"Marie\"\nlang = \"fr\"\ngreeting = \"hello\"\nword = \"Salut\"\n")
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig error: %v", err)
+	}
+	want := Config{Name: "Marie", Lang: "fr", Greeting: "hello", Word: "Salut"}
+	if cfg != want {
+		t.Errorf("LoadConfig = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestLoadConfigMissing(t *testing.T) {
+	cfg, err := LoadConfig(filepath.Join(t.TempDir(), "missing.toml"))
+	if err != nil {
+		t.Fatalf("LoadConfig error: %v", err)
+	}
+	if cfg != (Config{}) {
+		t.Errorf("LoadConfig = %+v, want zero Config", cfg)
+	}
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	for _, content := range []string{"name = ", "nmae = \"typo\"\n"} {
+		if _, err := LoadConfig(writeConfig(t, content)); err == nil {
+			t.Errorf("LoadConfig(%q) returned nil error", content)
+		}
+	}
+}
+
+func TestLoadConfigError(t *testing.T) {
+	for _, content := range []string{"name = ", "nmae = \"typo\"\n"} {
+		path := writeConfig(t, content)
+		_, err := LoadConfig(path)
+		var ce ConfigError
+		if !errors.As(err, &ce) {
+			t.Fatalf("LoadConfig(%q) error %v is not a ConfigError", content, err)
+		}
+		if ce.Path != path || ce.Err == nil {
+			t.Errorf("ConfigError = %+v, want path %q and an underlying error", ce, path)
+		}
+		if !strings.HasPrefix(err.Error(), "loading config "+path+": ") {
+			t.Errorf("Error() = %q, want config path prefix", err.Error())
+		}
+	}
+
+	// Errors other than a missing file are wrapped, not replaced.
+	dir := t.TempDir()
+	_, err := LoadConfig(dir)
+	var ce ConfigError
+	if !errors.As(err, &ce) {
+		t.Fatalf("LoadConfig(directory) error %v is not a ConfigError", err)
+	}
+	if errors.Unwrap(err) != ce.Err {
+		t.Errorf("Unwrap(%v) did not return the underlying error", err)
+	}
+}
+
+func TestRunConfigPrecedence(t *testing.T) {
+	path := writeConfig(t, "name = \"Marie\"\nlang = \"fr\"\n")
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"default", []string{"-config=" + filepath.Join(t.TempDir(), "none.toml")}, "Hello, World! 🐹\n"},
+		{"config", []string{"-config=" + path}, "Bonjour, Marie ! 👋\n"},
+		{"flag", []string{"-config=" + path, "-lang=de"}, "Hallo, Marie! 👋\n"},
+		{"argument", []string{"-config=" + path, "-lang=es", "Ana"}, "¡Hola, Ana! 👋\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"hello-go"}, tt.args...)
+			if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, nil, nil); code != 0 {
+				t.Fatalf("exit code = %d, want 0 (stderr %q)", code, stderr.String())
+			}
+			if got := stdout.String(); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("stdout = %q, want prefix %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunConfigError(t *testing.T) {
+	var stderr bytes.Buffer
+	path := writeConfig(t, "lang = 42\n")
+	if code := run(context.Background(), []string{"hello-go", "-config=" + path}, nil, io.Discard, &stderr, noEnv, nil, nil); code == 0 {
+		t.Error("exit code = 0, want failure")
+	}
+	if !strings.Contains(stderr.String(), "loading config") {
+		t.Errorf("stderr = %q, want config error", stderr.String())
+	}
+}
+
+func TestRunEnvPrecedence(t *testing.T) {
+	path := writeConfig(t, "name = \"Marie\"\nlang = \"fr\"\n")
+	env := fakeEnv(map[string]string{"HELLO_LANG": "es", "HELLO_NAME": "Ana"})
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"env beats config", []string{"-config=" + path}, "¡Hola, Ana! 👋\n"},
+		{"flag beats env", []string{"-config=" + path, "-lang=de"}, "Hallo, Ana! 👋\n"},
+		{"argument beats env", []string{"-config=" + path, "Luis"}, "¡Hola, Luis! 👋\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"hello-go"}, tt.args...)
+			if code := run(context.Background(), args, nil, &stdout, &stderr, env, nil, nil); code != 0 {
+				t.Fatalf("exit code = %d, want 0 (stderr %q)", code, stderr.String())
+			}
+			if got := stdout.String(); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("stdout = %q, want prefix %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunDefaultConfigPath(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "hello-go"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "hello-go", "config.toml"), []byte("lang = \"ja\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var stdout bytes.Buffer
+	env := fakeEnv(map[string]string{"XDG_CONFIG_HOME": dir})
+	if code := run(context.Background(), []string{"hello-go", "Yuki"}, nil, &stdout, io.Discard, env, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0", code)
+	}
+	if got, want := stdout.String(), "こんにちは、Yukiさん！🙇\n"; !strings.HasPrefix(got, want) {
+		t.Errorf("stdout = %q, want prefix %q", got, want)
+	}
+}
+
+func TestRunUsageDocumentsPrecedence(t *testing.T) {
+	var stderr bytes.Buffer
+	if code := run(context.Background(), []string{"hello-go", "-h"}, nil, io.Discard, &stderr, noEnv, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0", code)
+	}
+	for _, want := range []string{"HELLO_NAME", "HELLO_LANG", "HELLO_GREETING", "config file"} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("usage does not mention %q:\n%s", want, stderr.String())
+		}
+	}
+}
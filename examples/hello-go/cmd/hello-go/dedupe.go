@@ -0,0 +1,49 @@
+package main
+
+import "github.com/while-basic/enact-template/examples/hello-go/greet"
+
+// nameSet remembers the names seen so far for -dedupe. It keeps every
+// distinct name, so its memory grows with the number of distinct names in
+// the input, which matters for very large streams.
+type nameSet struct {
+	seen      map[string]struct{}
+	normalize bool
+}
+
+// newNameSet returns an empty nameSet. With normalize, names are compared
+// after NormalizeName, so that canonically equivalent spellings count as the
+// same name; otherwise they are compared byte for byte.
+func newNameSet(normalize bool) *nameSet {
+	return &nameSet{seen: map[string]struct{}{}, normalize: normalize}
+}
+
+// first reports whether name has not been seen before and records it. A nil
+// nameSet reports every name as new.
+func (s *nameSet) first(name string) bool {
+	if s == nil {
+		return true
+	}
+	if s.normalize {
+		name = greet.NormalizeName(name)
+	}
+	if _, ok := s.seen[name]; ok {
+		return false
+	}
+	s.seen[name] = struct{}{}
+	return true
+}
+
+// filter returns the names that have not been seen before, in their original
+// order, recording them.
+func (s *nameSet) filter(names []string) []string {
+	if s == nil {
+		return names
+	}
+	var kept []string
+	for _, name := range names {
+		if s.first(name) {
+			kept = append(kept, name)
+		}
+	}
+	return kept
+}
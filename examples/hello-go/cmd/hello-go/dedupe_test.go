@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const (
+	joseNFC = "Jos\u00e9"  // é as a single code point
+	joseNFD = "Jose\u0301" // e followed by a combining acute accent
+)
+
+func TestNameSet(t *testing.T) {
+	names := []string{"Ana", joseNFC, "Ana", joseNFD, "Bob", joseNFC}
+	tests := []struct {
+		normalize bool
+		want      []string
+	}{
+		{false, []string{"Ana", joseNFC, joseNFD, "Bob"}},
+		{true, []string{"Ana", joseNFC, "Bob"}},
+	}
+	for _, tt := range tests {
+		if got := newNameSet(tt.normalize).filter(names); !slices.Equal(got, tt.want) {
+			t.Errorf("normalize=%v: filter = %q, want %q", tt.normalize, got, tt.want)
+		}
+	}
+	var none *nameSet
+	if got := none.filter(names); !slices.Equal(got, names) {
+		t.Errorf("nil filter = %q, want all names", got)
+	}
+}
+
+func TestRunDedupe(t *testing.T) {
+	stdin := strings.Join([]string{"Ana", joseNFC, "Ana", "", joseNFD, "Bob", "Ana"}, "\n")
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"-dedupe"}, []string{"Ana", joseNFC, joseNFD, "Bob"}},
+		{[]string{"-dedupe", "-normalize"}, []string{"Ana", joseNFC, "Bob"}},
+		{[]string{"-dedupe", "-concurrency=4"}, []string{"Ana", joseNFC, joseNFD, "Bob"}},
+		{[]string{"-dedupe", "Ana", "Bob", "Ana"}, []string{"Ana", "Bob"}},
+		{nil, []string{"Ana", joseNFC, "Ana", joseNFD, "Bob", "Ana"}},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		args := append([]string{"hello-go", "-emoji=none", "-template={{.Name}}"}, tt.args...)
+		if code := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr, noEnv, nil, nil); code != 0 {
+			t.Fatalf("%q: exit code = %d, want 0 (stderr %q)", tt.args, code, stderr.String())
+		}
+		lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+		if got := lines[:len(lines)-1]; !slices.Equal(got, tt.want) {
+			t.Errorf("%q: greeted %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
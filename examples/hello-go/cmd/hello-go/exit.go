@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Exit codes returned by run.
+const (
+	exitOK      = 0 // success
+	exitFailure = 1 // any other failure, such as an unwritable stdout
+	exitUsage   = 2 // invalid flags, arguments or option values
+	exitLocale  = 3 // unsupported -lang
+	exitIO      = 4 // reading stdin, the config or locale file, or creating or writing -output failed
+
+	exitInterrupted = 130 // interrupted by SIGINT, as shells report it
+)
+
+// exitError is an error that ends the process with a specific exit code. An
+// exitError with a nil err has already been reported to the user, as the
+// flag package does for parse errors.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit status %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// usageErrorf returns an exitError with exitUsage for a formatted message.
+func usageErrorf(format string, args ...any) error {
+	return &exitError{exitUsage, fmt.Errorf(format, args...)}
+}
+
+// exitCode reports err on stderr and returns the exit code it maps to.
+// Errors that are not exitErrors map to exitFailure.
+func exitCode(err error, stderr io.Writer) int {
+	if err == nil {
+		return exitOK
+	}
+	var ee *exitError
+	if !errors.As(err, &ee) {
+		ee = &exitError{exitFailure, err}
+	}
+	if ee.err != nil {
+		fmt.Fprintf(stderr, "hello-go: %v\n", err)
+	}
+	return ee.code
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestRunExitCodes(t *testing.T) {
+	badConfig := writeConfig(t, "lang = \n")
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  io.Reader
+		code   int
+		stderr string
+	}{
+		{"ok", []string{"Sam"}, nil, exitOK, ""},
+		{"bad flag", []string{"-nope"}, nil, exitUsage, "flag provided but not defined"},
+		{"bad flag value", []string{"-repeat=0"}, nil, exitUsage, "-repeat must be at least 1"},
+		{"unknown lang", []string{"-lang=xx"}, nil, exitLocale, `unsupported locale "xx"`},
+		{"stdin read error", nil, iotest.ErrReader(errors.New("disk on fire")), exitIO, "reading names: disk on fire"},
+		{"config read error", []string{"-config=" + badConfig}, nil, exitIO, "loading config"},
+		{"completion without shell", []string{"completion"}, nil, exitUsage, "usage: hello-go completion"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			args := append([]string{"hello-go"}, tt.args...)
+			if code := run(context.Background(), args, tt.stdin, io.Discard, &stderr, noEnv, nil, nil); code != tt.code {
+				t.Errorf("exit code = %d, want %d (stderr %q)", code, tt.code, stderr.String())
+			}
+			if !strings.Contains(stderr.String(), tt.stderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr.String(), tt.stderr)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	var stderr bytes.Buffer
+	if code := exitCode(errors.New("boom"), &stderr); code != exitFailure {
+		t.Errorf("plain error: exit code = %d, want %d", code, exitFailure)
+	}
+	if got := stderr.String(); got != "hello-go: boom\n" {
+		t.Errorf("stderr = %q", got)
+	}
+	stderr.Reset()
+	if code := exitCode(&exitError{code: exitUsage}, &stderr); code != exitUsage || stderr.Len() != 0 {
+		t.Errorf("reported error: exit code = %d, stderr = %q", code, stderr.String())
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A Source says where the value of a setting came from.
+type Source string
+
+// Sources of settings, in decreasing precedence.
+const (
+	SourceFlag    Source = "flag"
+	SourceEnv     Source = "env"
+	SourceConfig  Source = "config"
+	SourceDefault Source = "default"
+)
+
+// A Setting is a resolved setting together with its provenance.
+type Setting struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source Source `json:"source"`
+	// From names the flag, environment variable or config file that set
+	// the value; it is empty for defaults.
+	From string `json:"from,omitempty"`
+}
+
+// ResolvedConfig is the configuration the greet command runs with, as
+// reported by -explain: every setting in the order it was resolved.
+type ResolvedConfig struct {
+	Settings []Setting `json:"settings"`
+}
+
+// addFlags records the flags of fs that are not recorded yet, with their
+// source depending on whether they were set on the command line.
+func (c *ResolvedConfig) addFlags(fs *flag.FlagSet, explicit map[string]bool) {
+	fs.VisitAll(func(f *flag.Flag) {
+		if _, ok := c.Get(f.Name); ok {
+			return
+		}
+		s := Setting{Name: f.Name, Value: f.Value.String(), Source: SourceDefault}
+		if explicit[f.Name] {
+			s.Source, s.From = SourceFlag, "-"+f.Name
+		}
+		c.Settings = append(c.Settings, s)
+	})
+}
+
+// Get returns the setting named name and whether there is one.
+func (c *ResolvedConfig) Get(name string) (Setting, bool) {
+	for _, s := range c.Settings {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Setting{}, false
+}
+
+// String renders c one setting per line, such as
+//
+//	lang=fr (from $HELLO_LANG)
+//	name=Marie (from config file /home/marie/.config/hello-go/config.toml)
+//	greeting=hello (default)
+func (c *ResolvedConfig) String() string {
+	var b strings.Builder
+	for _, s := range c.Settings {
+		switch s.Source {
+		case SourceDefault:
+			fmt.Fprintf(&b, "%s=%s (default)\n", s.Name, s.Value)
+		case SourceConfig:
+			fmt.Fprintf(&b, "%s=%s (from config file %s)\n", s.Name, s.Value, s.From)
+		default:
+			fmt.Fprintf(&b, "%s=%s (from %s)\n", s.Name, s.Value, s.From)
+		}
+	}
+	return b.String()
+}
+
+// write writes c to w in format: JSON for formatJSON, String otherwise.
+func (c *ResolvedConfig) write(w io.Writer, format string) error {
+	if format != formatJSON {
+		return writeAll(w, c.String())
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeAll(w, string(data)+"\n")
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRunExplain(t *testing.T) {
+	path := writeConfig(t, "name = \"Marie\"\nlang = \"de\"\n")
+	env := fakeEnv(map[string]string{"HELLO_LANG": "fr"})
+	var stdout, stderr bytes.Buffer
+	args := []string{"hello-go", "-explain", "-config=" + path, "-word=Salut", "Sam"}
+	if code := run(context.Background(), args, nil, &stdout, &stderr, env, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+	got := stdout.String()
+	for _, want := range []string{
+		"lang=fr (from $HELLO_LANG)\n",
+		"word=Salut (from -word)\n",
+		"name=Marie (from config file " + path + ")\n",
+		"greeting=hello (default)\n",
+		"format=text (default)\n",
+		"explain=true (from -explain)\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("stdout does not contain %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Salut, Sam") {
+		t.Errorf("stdout = %q, want no greeting", got)
+	}
+}
+
+func TestRunExplainJSON(t *testing.T) {
+	env := fakeEnv(map[string]string{"HELLO_LANG": "fr", "HELLO_GREETING": "timeofday"})
+	var stdout bytes.Buffer
+	args := []string{"hello-go", "-explain", "-format=json", "-config=", "-greeting=hello"}
+	if code := run(context.Background(), args, nil, &stdout, &stdout, env, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0 (output %q)", code, stdout.String())
+	}
+	var rc ResolvedConfig
+	if err := json.Unmarshal(stdout.Bytes(), &rc); err != nil {
+		t.Fatalf("invalid JSON %q: %v", stdout.String(), err)
+	}
+	want := map[string]Setting{
+		"lang":     {Name: "lang", Value: "fr", Source: SourceEnv, From: "$HELLO_LANG"},
+		"greeting": {Name: "greeting", Value: "hello", Source: SourceFlag, From: "-greeting"},
+		"name":     {Name: "name", Value: "World", Source: SourceDefault},
+		"format":   {Name: "format", Value: "json", Source: SourceFlag, From: "-format"},
+	}
+	for name, w := range want {
+		if s, ok := rc.Get(name); !ok || s != w {
+			t.Errorf("setting %s = %+v, want %+v", name, s, w)
+		}
+	}
+}
+
+func TestResolvedConfigString(t *testing.T) {
+	rc := ResolvedConfig{Settings: []Setting{
+		{Name: "lang", Value: "fr", Source: SourceEnv, From: "$HELLO_LANG"},
+		{Name: "word", Value: "", Source: SourceDefault},
+	}}
+	if got, want := rc.String(), "lang=fr (from $HELLO_LANG)\nword= (default)\n"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// goldenTests are run and their stdout compared with
+// testdata/golden/<name>.golden. Colors are only tested with -color=always,
+// which does not depend on whether stdout is a terminal.
+var goldenTests = []struct {
+	name  string
+	args  []string
+	stdin string
+}{
+	{"text", []string{"Alice", "Bob"}, ""},
+	{"text-stdin", nil, "Alice\n\n  Bob  \nCarol\n"},
+	{"locales", []string{"-lang=ja", "Ken"}, ""},
+	{"rtl", []string{"-lang=ar", "Sam"}, ""},
+	{"json", []string{"-format=json", "Alice", "Bob"}, ""},
+	{"csv", []string{"-format=csv", `Smith, "Jo"`, "Bob"}, ""},
+	{"color", []string{"-color=always", "-lang=fr", "Alice"}, ""},
+	{"group", []string{"-group", "Alice", "Bob", "Carol"}, ""},
+	{"group-fr-wrap", []string{"-group", "-lang=fr", "-wrap=20", "Alexandre", "Béatrice", "Camille"}, ""},
+	{"repeat", []string{"-repeat=2", "-emoji=🎉", "Alice", "Bob"}, ""},
+	{"count-only", []string{"-count-only", "-format=json"}, "Alice\nBob\nAlice\n"},
+	{"dedupe", []string{"-dedupe", "-title-case"}, "alice\nbob\nalice\n"},
+	{"template", []string{"-template={{upper .Name}} speaks {{.Locale}}", "-lang=de", "Alice"}, ""},
+	{"word", []string{"-word=Welcome", "-emoji=none", "Sam"}, ""},
+}
+
+// fixedVersion returns a Go version function for run that reports version.
+func fixedVersion(version string) func() string {
+	return func() string { return version }
+}
+
+func TestGolden(t *testing.T) {
+	for _, tt := range goldenTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"hello-go"}, tt.args...)
+			// The Go version depends on the toolchain running the tests.
+			if code := run(context.Background(), args, strings.NewReader(tt.stdin), &stdout, &stderr, noEnv, fixedVersion("GOVERSION"), nil); code != 0 {
+				t.Fatalf("exit code = %d, want 0 (stderr %q)", code, stderr.String())
+			}
+			got := stdout.String()
+
+			path := filepath.Join("testdata", "golden", tt.name+".golden")
+			if *update {
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%v (run go test -update to create it)", err)
+			}
+			if got != string(want) {
+				t.Errorf("stdout differs from %s:\ngot:\n%s\nwant:\n%s", path, got, want)
+			}
+		})
+	}
+}
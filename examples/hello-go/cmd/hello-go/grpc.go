@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+	"github.com/while-basic/enact-template/examples/hello-go/proto/greetpb"
+)
+
+// NewGRPCServer returns a gRPC server of the Greeter service in
+// proto/greet.proto, greeting like g.
+func NewGRPCServer(g *greet.Greeter) *grpc.Server {
+	var cur atomic.Pointer[greet.Greeter]
+	cur.Store(g)
+	return newGRPCServer(&cur)
+}
+
+// newGRPCServer is NewGRPCServer with the greeter read from cur at the start
+// of each call, like newHandler.
+func newGRPCServer(cur *atomic.Pointer[greet.Greeter]) *grpc.Server {
+	srv := grpc.NewServer()
+	greetpb.RegisterGreeterServer(srv, &greeterServer{cur: cur})
+	return srv
+}
+
+// greeterServer implements greetpb.GreeterServer.
+type greeterServer struct {
+	greetpb.UnimplementedGreeterServer
+	cur *atomic.Pointer[greet.Greeter]
+}
+
+func (s *greeterServer) Greet(ctx context.Context, req *greetpb.GreetRequest) (*greetpb.GreetReply, error) {
+	return greetReply(s.cur.Load(), req)
+}
+
+func (s *greeterServer) GreetMany(stream greetpb.Greeter_GreetManyServer) error {
+	g := s.cur.Load()
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		reply, err := greetReply(g, req)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(reply); err != nil {
+			return err
+		}
+	}
+}
+
+// greetReply greets req with g, in req's locale if it sets one. An
+// unsupported locale is reported with codes.InvalidArgument.
+func greetReply(g *greet.Greeter, req *greetpb.GreetRequest) (*greetpb.GreetReply, error) {
+	if l := req.GetLocale(); l != "" {
+		var err error
+		if g, err = g.With(greet.WithLocale(greet.Locale(l))); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	r := g.Result(req.GetName())
+	return &greetpb.GreetReply{Name: r.Name, Greeting: r.Greeting, GoVersion: r.GoVersion}, nil
+}
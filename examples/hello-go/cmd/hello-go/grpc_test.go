@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+	"github.com/while-basic/enact-template/examples/hello-go/proto/greetpb"
+)
+
+// grpcClient serves NewGRPCServer(g) over an in-memory connection and
+// returns a client of it.
+func grpcClient(t *testing.T, g *greet.Greeter) greetpb.GreeterClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := NewGRPCServer(g)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return greetpb.NewGreeterClient(conn)
+}
+
+func TestGRPCGreet(t *testing.T) {
+	c := grpcClient(t, newGreeter(t, greet.WithLocale(greet.German)))
+	tests := []struct {
+		req  *greetpb.GreetRequest
+		name string
+		want string
+	}{
+		{&greetpb.GreetRequest{}, "World", "Hallo, World! 👋"},
+		{&greetpb.GreetRequest{Name: " Ana "}, "Ana", "Hallo, Ana! 👋"},
+		{&greetpb.GreetRequest{Name: "Ana", Locale: "fr"}, "Ana", "Bonjour, Ana ! 👋"},
+	}
+	for _, tt := range tests {
+		reply, err := c.Greet(context.Background(), tt.req)
+		if err != nil {
+			t.Fatalf("Greet(%v) error: %v", tt.req, err)
+		}
+		if reply.GetName() != tt.name || reply.GetGreeting() != tt.want || reply.GetGoVersion() == "" {
+			t.Errorf("Greet(%v) = %v, want name %q and greeting %q", tt.req, reply, tt.name, tt.want)
+		}
+	}
+
+	_, err := c.Greet(context.Background(), &greetpb.GreetRequest{Locale: "xx"})
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Errorf("Greet with unsupported locale: code = %v, want %v (error %v)", code, codes.InvalidArgument, err)
+	}
+}
+
+func TestGRPCGreetMany(t *testing.T) {
+	c := grpcClient(t, newGreeter(t))
+	stream, err := c.GreetMany(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs := []*greetpb.GreetRequest{{Name: "Ana"}, {Name: "Luis", Locale: "es"}, {Name: "Yuki", Locale: "ja"}}
+	for _, req := range reqs {
+		if err := stream.Send(req); err != nil {
+			t.Fatalf("Send error: %v", err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for {
+		reply, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv error: %v", err)
+		}
+		got = append(got, reply.GetGreeting())
+	}
+	want := []string{"Hello, Ana! 🐹", "¡Hola, Luis! 👋", "こんにちは、Yukiさん！🙇"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d replies %q, want %q", len(got), got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("reply %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	stream, err = c.GreetMany(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&greetpb.GreetRequest{Name: "Ana", Locale: "xx"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GreetMany with unsupported locale: error = %v, want code %v", err, codes.InvalidArgument)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import "github.com/while-basic/enact-template/examples/hello-go/greet"
+
+// nameLimit applies -max-name-length and -on-too-long to the names to greet.
+type nameLimit struct {
+	max    int
+	policy greet.Policy
+}
+
+// apply returns name as limited by greet.ApplyLengthPolicy, and whether it
+// should be greeted. A nil nameLimit keeps every name unchanged.
+func (l *nameLimit) apply(name string) (string, bool, error) {
+	if l == nil {
+		return name, true, nil
+	}
+	return greet.ApplyLengthPolicy(name, l.max, l.policy)
+}
+
+// filter applies the limit to each of names, dropping the skipped ones. It
+// stops at the first rejected name.
+func (l *nameLimit) filter(names []string) ([]string, error) {
+	if l == nil {
+		return names, nil
+	}
+	var kept []string
+	for _, name := range names {
+		name, ok, err := l.apply(name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			kept = append(kept, name)
+		}
+	}
+	return kept, nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRunMaxNameLength(t *testing.T) {
+	stdin := "Ana\nMaximiliana\nBob\n"
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"-max-name-length=5"}, []string{"Ana", "Maxi…", "Bob"}},
+		{[]string{"-max-name-length=5", "-on-too-long=skip"}, []string{"Ana", "Bob"}},
+		{[]string{"-max-name-length=5", "-on-too-long=skip", "-concurrency=4"}, []string{"Ana", "Bob"}},
+		{[]string{"-max-name-length=5", "-on-too-long=skip", "Ana", "Maximiliana"}, []string{"Ana"}},
+		{[]string{"-max-name-length=5", "-dedupe", "Maximiliana", "Maximilian"}, []string{"Maxi…"}},
+		{[]string{"-on-too-long=reject"}, []string{"Ana", "Maximiliana", "Bob"}},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		args := append([]string{"hello-go", "-show-go-version=false", "-template={{.Name}}"}, tt.args...)
+		if code := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr, noEnv, nil, nil); code != 0 {
+			t.Fatalf("%q: exit code = %d, want 0 (stderr %q)", tt.args, code, stderr.String())
+		}
+		if got := strings.Fields(stdout.String()); !slices.Equal(got, tt.want) {
+			t.Errorf("%q: greeted %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestRunMaxNameLengthReject(t *testing.T) {
+	for _, args := range [][]string{{"-format=json"}, {"-concurrency=2"}, {"Ana", "Maximiliana"}} {
+		var stdout, stderr bytes.Buffer
+		args = append([]string{"hello-go", "-max-name-length=5", "-on-too-long=reject"}, args...)
+		if code := run(context.Background(), args, strings.NewReader("Ana\nMaximiliana\n"), &stdout, &stderr, noEnv, nil, nil); code != exitFailure {
+			t.Errorf("%q: exit code = %d, want %d", args, code, exitFailure)
+		}
+		if !strings.Contains(stderr.String(), "name too long") {
+			t.Errorf("%q: stderr = %q, want name too long", args, stderr.String())
+		}
+	}
+}
+
+func TestRunMaxNameLengthUsage(t *testing.T) {
+	for _, args := range [][]string{{"-max-name-length=-1"}, {"-on-too-long=shorten"}} {
+		args = append([]string{"hello-go"}, append(args, "Sam")...)
+		if code := run(context.Background(), args, nil, io.Discard, io.Discard, noEnv, nil, nil); code != exitUsage {
+			t.Errorf("%q: exit code = %d, want %d", args, code, exitUsage)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+// LoadLocaleFile reads a JSON object mapping locale codes to greeting
+// templates, such as {"pt": "Olá, %s! %s"}, from path. Every template must
+// pass greet.ValidateTemplate.
+func LoadLocaleFile(path string) (map[greet.Locale]string, error) {
+	templates, err := readLocaleFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if errs := checkTemplates(templates); len(errs) > 0 {
+		return nil, fmt.Errorf("loading locale file %s: %w", path, errs[0])
+	}
+	return templates, nil
+}
+
+// readLocaleFile parses the locale file at path without validating the
+// templates.
+func readLocaleFile(path string) (map[greet.Locale]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading locale file: %w", err)
+	}
+	var templates map[greet.Locale]string
+	if err := json.Unmarshal(data, &templates); err != nil {
+		return nil, fmt.Errorf("loading locale file %s: %w", path, err)
+	}
+	return templates, nil
+}
+
+// checkTemplates returns a problem for each invalid entry of templates, in
+// locale order.
+func checkTemplates(templates map[greet.Locale]string) []error {
+	var errs []error
+	for _, l := range slices.Sorted(maps.Keys(templates)) {
+		if l == "" {
+			errs = append(errs, errors.New("empty locale code"))
+		} else if err := greet.ValidateTemplate(templates[l]); err != nil {
+			errs = append(errs, fmt.Errorf("locale %q: %w", l, err))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+func writeLocaleFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "greetings.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadLocaleFile(t *testing.T) {
+	path := writeLocaleFile(t, `{"pt": "Olá, %s! %s", "en": "Howdy, %s! %s"}`)
+	got, err := LoadLocaleFile(path)
+	if err != nil {
+		t.Fatalf("LoadLocaleFile error: %v", err)
+	}
+	if len(got) != 2 || got["pt"] != "Olá, %s! %s" || got[greet.English] != "Howdy, %s! %s" {
+		t.Errorf("LoadLocaleFile = %q", got)
+	}
+}
+
+func TestLoadLocaleFileInvalid(t *testing.T) {
+	for _, content := range []string{
+		`{"pt": "Olá!"}`,
+		`{"": "Hi, %s!"}`,
+		`["Hi, %s!"]`,
+		`{"pt": `,
+	} {
+		if _, err := LoadLocaleFile(writeLocaleFile(t, content)); err == nil {
+			t.Errorf("LoadLocaleFile(%s) returned nil error", content)
+		}
+	}
+	if _, err := LoadLocaleFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadLocaleFile(missing) returned nil error")
+	}
+}
+
+func TestRunLocaleFile(t *testing.T) {
+	path := writeLocaleFile(t, `{"pt": "Olá, %s! %s", "en": "Howdy, %s! %s"}`)
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-lang=pt", "Ana"}, "Olá, Ana! 🐹\n"},
+		{[]string{"Sam"}, "Howdy, Sam! 🐹\n"},
+		{[]string{"-lang=fr", "Sam"}, "Bonjour, Sam ! 👋\n"},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		args := append([]string{"hello-go", "-locale-file=" + path}, tt.args...)
+		if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, nil, nil); code != 0 {
+			t.Fatalf("%q: exit code = %d, want 0 (stderr %q)", tt.args, code, stderr.String())
+		}
+		if got := stdout.String(); !strings.HasPrefix(got, tt.want) {
+			t.Errorf("%q: stdout = %q, want prefix %q", tt.args, got, tt.want)
+		}
+	}
+
+	if code := run(context.Background(), []string{"hello-go", "-lang=pt", "Ana"}, nil, io.Discard, io.Discard, noEnv, nil, nil); code != exitLocale {
+		t.Errorf("-lang=pt without locale file: exit code = %d, want %d", code, exitLocale)
+	}
+	bad := writeLocaleFile(t, `{"pt": "Olá!"}`)
+	var stderr bytes.Buffer
+	if code := run(context.Background(), []string{"hello-go", "-locale-file=" + bad, "Ana"}, nil, io.Discard, &stderr, noEnv, nil, nil); code != exitIO {
+		t.Errorf("invalid locale file: exit code = %d, want %d", code, exitIO)
+	}
+	if !strings.Contains(stderr.String(), "placeholder") {
+		t.Errorf("stderr = %q, want placeholder error", stderr.String())
+	}
+}
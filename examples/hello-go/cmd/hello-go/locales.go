@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+// LocaleInfo describes a locale listed by the locales subcommand.
+type LocaleInfo struct {
+	Locale   greet.Locale `json:"locale"`
+	Language string       `json:"language"`
+	Emoji    string       `json:"emoji"` // "" if the template has no emoji
+	Example  string       `json:"example"`
+}
+
+// ListLocales describes greet.SupportedLocales together with the locales of
+// custom, a locale file's templates, sorted by code. The example greets
+// greet.DefaultName; custom templates override the built-in ones.
+func ListLocales(custom map[greet.Locale]string) ([]LocaleInfo, error) {
+	codes := append(greet.SupportedLocales(), slices.Collect(maps.Keys(custom))...)
+	slices.Sort(codes)
+	codes = slices.Compact(codes)
+	base, err := greet.New(greet.WithTemplates(custom), greet.WithGoVersion(nil))
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]LocaleInfo, len(codes))
+	for i, l := range codes {
+		g, err := base.With(greet.WithLocale(l))
+		if err != nil {
+			return nil, err
+		}
+		emoji := l.DefaultEmoji()
+		if tmpl, ok := custom[l]; ok && strings.Count(tmpl, "%s") < 2 {
+			emoji = ""
+		}
+		infos[i] = LocaleInfo{l, languageName(l), emoji, g.Greet("")}
+	}
+	return infos, nil
+}
+
+// languageName returns the English name of the language of l, or "" if l
+// is not a known language code.
+func languageName(l greet.Locale) string {
+	tag, err := language.Parse(string(l))
+	if err != nil {
+		return ""
+	}
+	if _, conf := tag.Base(); conf == language.No {
+		return ""
+	}
+	return display.English.Languages().Name(tag)
+}
+
+// writeLocales writes infos to w as an aligned table, or as a JSON array for
+// formatJSON. Table cells are padded by their width on a terminal, where an
+// emoji takes two columns; text/tabwriter would count it as one.
+func writeLocales(w io.Writer, infos []LocaleInfo, format string) error {
+	if format == formatJSON {
+		data, err := json.MarshalIndent(infos, "", "  ")
+		if err != nil {
+			return err
+		}
+		return writeAll(w, string(data)+"\n")
+	}
+	rows := [][]string{{"LOCALE", "LANGUAGE", "EMOJI", "EXAMPLE"}}
+	for _, info := range infos {
+		row := []string{string(info.Locale), info.Language, info.Emoji, info.Example}
+		for i, cell := range row[:3] {
+			if cell == "" {
+				row[i] = "-"
+			}
+		}
+		rows = append(rows, row)
+	}
+	// The last column is not padded.
+	widths := make([]int, len(rows[0])-1)
+	for _, row := range rows {
+		for i := range widths {
+			widths[i] = max(widths[i], uniseg.StringWidth(row[i]))
+		}
+	}
+	var b strings.Builder
+	for _, row := range rows {
+		for i, width := range widths {
+			b.WriteString(row[i])
+			b.WriteString(strings.Repeat(" ", width-uniseg.StringWidth(row[i])+2))
+		}
+		b.WriteString(row[len(row)-1])
+		b.WriteByte('\n')
+	}
+	return writeAll(w, b.String())
+}
+
+// localesCommand implements the locales subcommand: it lists the supported
+// locales, including those of -locale-file, with an example greeting each.
+func localesCommand(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("hello-go locales", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", formatText, "output format: text or json")
+	localeFile := fs.String("locale-file", "", "JSON `file` of extra greeting templates to list as well")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return &exitError{code: exitUsage}
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("locales: unexpected arguments %q", fs.Args())
+	}
+	switch *format {
+	case formatText, formatJSON:
+	default:
+		return usageErrorf("locales: unknown format %q (want text or json)", *format)
+	}
+	var custom map[greet.Locale]string
+	if *localeFile != "" {
+		var err error
+		if custom, err = LoadLocaleFile(*localeFile); err != nil {
+			return &exitError{exitIO, err}
+		}
+	}
+	infos, err := ListLocales(custom)
+	if err != nil {
+		return err
+	}
+	return writeLocales(stdout, infos, *format)
+}
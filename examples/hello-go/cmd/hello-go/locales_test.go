@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rivo/uniseg"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+func TestRunLocales(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run(context.Background(), []string{"hello-go", "locales"}, nil, &stdout, &stderr, noEnv, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	if got := strings.Fields(lines[0]); !reflect.DeepEqual(got, []string{"LOCALE", "LANGUAGE", "EMOJI", "EXAMPLE"}) {
+		t.Errorf("header = %q", lines[0])
+	}
+	counts := map[greet.Locale]int{}
+	for _, line := range lines[1:] {
+		counts[greet.Locale(strings.Fields(line)[0])]++
+	}
+	for _, l := range greet.SupportedLocales() {
+		if counts[l] != 1 {
+			t.Errorf("locale %q listed %d times, want once", l, counts[l])
+		}
+	}
+	if len(lines)-1 != len(greet.SupportedLocales()) {
+		t.Errorf("listed %d locales, want %d:\n%s", len(lines)-1, len(greet.SupportedLocales()), stdout.String())
+	}
+	// Columns are padded to the widest entry, Japanese in LANGUAGE, by
+	// display width: the emoji take two columns.
+	if want := "\nja      Japanese  🙇     こんにちは、Worldさん！🙇\n"; !strings.Contains(stdout.String(), want) {
+		t.Errorf("stdout does not contain %q:\n%s", want, stdout.String())
+	}
+	col := uniseg.StringWidth(lines[0][:strings.Index(lines[0], "EXAMPLE")])
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		i := strings.Index(line, fields[3])
+		if got := uniseg.StringWidth(line[:i]); got != col {
+			t.Errorf("line %q: example starts at column %d, want %d", line, got, col)
+		}
+	}
+
+	// A custom template without an emoji placeholder has no emoji.
+	stdout.Reset()
+	args := []string{"hello-go", "locales", "-locale-file", writeLocaleFile(t, `{"x-bow": "%s, bow"}`)}
+	if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, nil, nil); code != 0 {
+		t.Fatalf("-locale-file: exit code = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+	if want := "\nx-bow   -         -      World, bow\n"; !strings.Contains(stdout.String(), want) {
+		t.Errorf("-locale-file: stdout does not contain %q:\n%s", want, stdout.String())
+	}
+}
+
+func TestRunLocalesJSON(t *testing.T) {
+	path := writeLocaleFile(t, `{"pt": "Olá, %s! %s", "fr": "Salut, %s ! %s", "x-bow": "%s, bow"}`)
+	var stdout, stderr bytes.Buffer
+	args := []string{"hello-go", "locales", "-format=json", "-locale-file", path}
+	if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+	var infos []LocaleInfo
+	if err := json.Unmarshal(stdout.Bytes(), &infos); err != nil {
+		t.Fatalf("invalid JSON %q: %v", stdout.String(), err)
+	}
+	want, err := ListLocales(map[greet.Locale]string{"pt": "Olá, %s! %s", "fr": "Salut, %s ! %s", "x-bow": "%s, bow"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(infos, want) {
+		t.Errorf("round trip = %+v, want %+v", infos, want)
+	}
+	byLocale := map[greet.Locale]LocaleInfo{}
+	for _, info := range infos {
+		byLocale[info.Locale] = info
+	}
+	if len(byLocale) != len(greet.SupportedLocales())+2 {
+		t.Errorf("listed %d distinct locales, want the built-in ones plus pt and x-bow", len(byLocale))
+	}
+	checks := map[greet.Locale]LocaleInfo{
+		"pt":          {"pt", "Portuguese", greet.DefaultEmoji, "Olá, World! 🐹"},
+		greet.French:  {greet.French, "French", "👋", "Salut, World ! 👋"},
+		greet.Hebrew:  {greet.Hebrew, "Hebrew", "👋", "שלום, \u2068World\u2069! 👋"},
+		"x-bow":       {"x-bow", "", "", "World, bow"},
+		greet.English: {greet.English, "English", "🐹", "Hello, World! 🐹"},
+	}
+	for l, w := range checks {
+		if got := byLocale[l]; got != w {
+			t.Errorf("locale %q = %+v, want %+v", l, got, w)
+		}
+	}
+}
+
+func TestRunLocalesInvalid(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{[]string{"-format=csv"}, exitUsage},
+		{[]string{"extra"}, exitUsage},
+		{[]string{"-locale-file", writeLocaleFile(t, `{"pt": "Olá!"}`)}, exitIO},
+	}
+	for _, tt := range tests {
+		args := append([]string{"hello-go", "locales"}, tt.args...)
+		if code := run(context.Background(), args, nil, io.Discard, io.Discard, noEnv, nil, nil); code != tt.code {
+			t.Errorf("%q: exit code = %d, want %d", tt.args, code, tt.code)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// newLogger returns the default logger of run, writing text records of every
+// level to w. run narrows it with minLevelHandler according to -verbose.
+func newLogger(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// Log destinations accepted by serve -log.
+const (
+	logStderr = "stderr"
+	logJSON   = "json"
+	logSyslog = "syslog"
+)
+
+// newLogHandler returns the slog handler for the log destination kind: text
+// records on w for logStderr, JSON records on w for logJSON, and the system
+// logger for logSyslog, which ignores w. Syslog is not available on Windows
+// and Plan 9, where selecting it is an error.
+func newLogHandler(kind string, w io.Writer) (slog.Handler, error) {
+	switch kind {
+	case logStderr:
+		return slog.NewTextHandler(w, nil), nil
+	case logJSON:
+		return slog.NewJSONHandler(w, nil), nil
+	case logSyslog:
+		return newSyslogHandler()
+	}
+	return nil, fmt.Errorf("unknown log destination %q (want stderr, json or syslog)", kind)
+}
+
+// minLevelHandler drops records below min and passes the rest on to the
+// wrapped handler.
+type minLevelHandler struct {
+	min slog.Level
+	slog.Handler
+}
+
+func (h minLevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.min && h.Handler.Enabled(ctx, level)
+}
+
+func (h minLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return minLevelHandler{h.min, h.Handler.WithAttrs(attrs)}
+}
+
+func (h minLevelHandler) WithGroup(name string) slog.Handler {
+	return minLevelHandler{h.min, h.Handler.WithGroup(name)}
+}
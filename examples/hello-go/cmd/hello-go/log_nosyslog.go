@@ -0,0 +1,14 @@
+//go:build windows || plan9
+
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+)
+
+// newSyslogHandler reports that this platform has no syslog.
+func newSyslogHandler() (slog.Handler, error) {
+	return nil, fmt.Errorf("-log=syslog is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,77 @@
+//go:build !windows && !plan9
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"log/syslog"
+	"strings"
+	"sync"
+)
+
+// dialSyslog connects to the system logger; tests replace it.
+var dialSyslog = func() (*syslog.Writer, error) {
+	return syslog.New(syslog.LOG_DAEMON|syslog.LOG_INFO, "hello-go")
+}
+
+// newSyslogHandler returns a handler logging to the system logger.
+func newSyslogHandler() (slog.Handler, error) {
+	w, err := dialSyslog()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	text := slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})
+	return &syslogHandler{w: w, mu: new(sync.Mutex), buf: &buf, text: text}, nil
+}
+
+// dropTime removes the time from records, since syslog stamps them itself.
+func dropTime(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && a.Key == slog.TimeKey {
+		return slog.Attr{}
+	}
+	return a
+}
+
+// syslogHandler formats records with a text handler and sends each one to
+// syslog at the priority matching its level.
+type syslogHandler struct {
+	w    *syslog.Writer
+	mu   *sync.Mutex   // guards buf, which text and its derived handlers share
+	buf  *bytes.Buffer // the record being formatted
+	text slog.Handler
+}
+
+func (h *syslogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.text.Enabled(ctx, level)
+}
+
+func (h *syslogHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.buf.Reset()
+	if err := h.text.Handle(ctx, r); err != nil {
+		return err
+	}
+	msg := strings.TrimSuffix(h.buf.String(), "\n")
+	switch {
+	case r.Level >= slog.LevelError:
+		return h.w.Err(msg)
+	case r.Level >= slog.LevelWarn:
+		return h.w.Warning(msg)
+	case r.Level >= slog.LevelInfo:
+		return h.w.Info(msg)
+	default:
+		return h.w.Debug(msg)
+	}
+}
+
+func (h *syslogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &syslogHandler{h.w, h.mu, h.buf, h.text.WithAttrs(attrs)}
+}
+
+func (h *syslogHandler) WithGroup(name string) slog.Handler {
+	return &syslogHandler{h.w, h.mu, h.buf, h.text.WithGroup(name)}
+}
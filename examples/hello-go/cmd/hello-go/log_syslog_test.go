@@ -0,0 +1,58 @@
+//go:build !windows && !plan9
+
+package main
+
+import (
+	"log/slog"
+	"log/syslog"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSyslogHandler(t *testing.T) {
+	sock := filepath.Join(t.TempDir(), "log")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: sock, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("unixgram socket: %v", err)
+	}
+	defer conn.Close()
+	orig := dialSyslog
+	t.Cleanup(func() { dialSyslog = orig })
+	dialSyslog = func() (*syslog.Writer, error) {
+		return syslog.Dial("unixgram", sock, syslog.LOG_DAEMON|syslog.LOG_INFO, "hello-go")
+	}
+
+	h, err := newLogHandler(logSyslog, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := slog.New(h).With("addr", ":8080")
+	tests := []struct {
+		log  func(msg string, args ...any)
+		pri  string // LOG_DAEMON (3<<3) plus the severity
+		want string
+	}{
+		{logger.Error, "<27>", `level=ERROR msg=serving addr=:8080 err=boom`},
+		{logger.Warn, "<28>", `level=WARN msg=serving addr=:8080 err=boom`},
+		{logger.Info, "<30>", `level=INFO msg=serving addr=:8080 err=boom`},
+	}
+	buf := make([]byte, 1024)
+	for _, tt := range tests {
+		tt.log("serving", "err", "boom")
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, err := conn.Read(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := string(buf[:n])
+		if !strings.HasPrefix(got, tt.pri) || !strings.Contains(got, "hello-go[") || !strings.HasSuffix(strings.TrimSuffix(got, "\n"), "]: "+tt.want) {
+			t.Errorf("syslog message = %q, want priority %s and %q", got, tt.pri, tt.want)
+		}
+		if strings.Contains(got, "time=") {
+			t.Errorf("syslog message %q has a time attribute", got)
+		}
+	}
+}
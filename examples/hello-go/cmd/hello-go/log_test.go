@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestRunVerbose(t *testing.T) {
+	for _, verbose := range []bool{false, true} {
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		args := []string{"hello-go", "-lang=fr", "Marie"}
+		if verbose {
+			args = append([]string{"hello-go", "-verbose"}, args[1:]...)
+		}
+		var stdout bytes.Buffer
+		if code := run(context.Background(), args, nil, &stdout, io.Discard, noEnv, nil, logger); code != 0 {
+			t.Fatalf("verbose=%v: exit code = %d, want 0", verbose, code)
+		}
+		hasLocale := strings.Contains(logs.String(), "level=DEBUG msg=\"resolved settings\" locale=fr")
+		if hasLocale != verbose {
+			t.Errorf("verbose=%v: locale debug line present = %v, logs:\n%s", verbose, hasLocale, logs.String())
+		}
+		if !strings.HasPrefix(stdout.String(), "Bonjour, Marie ! 👋\n") {
+			t.Errorf("verbose=%v: stdout = %q, want greeting", verbose, stdout.String())
+		}
+	}
+}
+
+func TestMinLevelHandler(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(minLevelHandler{slog.LevelWarn, slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})})
+	logger.With("k", "v").Info("dropped")
+	logger.WithGroup("g").Warn("kept")
+	if out := logs.String(); strings.Contains(out, "dropped") || !strings.Contains(out, "kept") {
+		t.Errorf("logs = %q, want only the warning", out)
+	}
+}
+
+func TestNewLogHandler(t *testing.T) {
+	var out bytes.Buffer
+	h, err := newLogHandler(logStderr, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slog.New(h).Info("serving HTTP", "addr", ":8080")
+	if got := out.String(); !strings.Contains(got, `level=INFO msg="serving HTTP" addr=:8080`) {
+		t.Errorf("stderr log = %q, want a text record", got)
+	}
+
+	out.Reset()
+	if h, err = newLogHandler(logJSON, &out); err != nil {
+		t.Fatal(err)
+	}
+	slog.New(h).Warn("reload failed", "path", "hello-go.toml")
+	var rec map[string]any
+	if err := json.Unmarshal(out.Bytes(), &rec); err != nil {
+		t.Fatalf("json log %q: %v", out.String(), err)
+	}
+	if rec["level"] != "WARN" || rec["msg"] != "reload failed" || rec["path"] != "hello-go.toml" {
+		t.Errorf("json log = %v", rec)
+	}
+
+	if _, err := newLogHandler("file", &out); err == nil {
+		t.Error("newLogHandler(\"file\") returned nil error")
+	}
+}
+
+func TestRunServeLogInvalid(t *testing.T) {
+	var stderr bytes.Buffer
+	if code := runServe(context.Background(), []string{"-log=file"}, &stderr, noEnv); code != exitUsage {
+		t.Errorf("exit code = %d, want %d", code, exitUsage)
+	}
+	if !strings.Contains(stderr.String(), "unknown -log") {
+		t.Errorf("stderr = %q, want unknown -log message", stderr.String())
+	}
+}
@@ -0,0 +1,512 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"text/template"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+// Greeting styles accepted by -greeting.
+const (
+	styleHello     = "hello"
+	styleTimeOfDay = "timeofday"
+)
+
+// Color modes accepted by -color.
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+// ANSI escapes wrapped around the name when color is enabled.
+const (
+	ansiName  = "\x1b[1;96m"
+	ansiReset = "\x1b[0m"
+)
+
+// emojiNone is the -emoji value that drops the emoji.
+const emojiNone = "none"
+
+// Output formats accepted by -format.
+const (
+	formatText = "text"
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+func main() {
+	// Report writes to a closed pipe as EPIPE errors instead of dying of
+	// SIGPIPE, so that they can end the program with status 0.
+	ignoreSIGPIPE()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args, os.Stdin, os.Stdout, os.Stderr, os.Getenv, runtime.Version, nil)
+	stop()
+	os.Exit(code)
+}
+
+// usageFooter documents how settings are resolved; it is printed after the
+// flag defaults.
+const usageFooter = `
+Settings are resolved in this order, the first one set wins:
+  1. command-line flags and name arguments
+  2. environment variables HELLO_NAME, HELLO_LANG and HELLO_GREETING
+  3. the config file (-config) keys name, lang, greeting and word
+  4. built-in defaults
+
+Exit status is 0 on success, also when the reader of stdout exits early, 2
+for invalid flags or arguments, 3 for an unsupported language, 4 when
+reading stdin, the config file or the locale file or writing the -output
+file fails and 1 for any other error. Interrupting the program while it
+reads stdin exits with status 130 after writing the greetings produced so
+far.
+`
+
+// run parses args (including the program name), writes the greetings to
+// stdout and diagnostics to stderr, and returns the process exit code. Names
+// come from the positional arguments, or from stdin, one per line, when there
+// are none and stdin is not a terminal. Reading stdin stops when ctx is
+// canceled. A first argument naming a subcommand (version, completion,
+// serve, repl, check or locales) runs that subcommand instead.
+// Environment variables are looked up with env, which defaults to os.Getenv
+// when nil, and the Go version is reported by goVersion, which defaults to
+// runtime.Version when nil. Diagnostics are logged to logger, which defaults
+// to a text logger on stderr; records below warning level are dropped unless
+// -verbose is set. The exit codes are documented in usageFooter.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer, env func(string) string, goVersion func() string, logger *slog.Logger) int {
+	if env == nil {
+		env = os.Getenv
+	}
+	if goVersion == nil {
+		goVersion = runtime.Version
+	}
+	if logger == nil {
+		logger = newLogger(stderr)
+	}
+	if len(args) > 1 {
+		switch args[1] {
+		case "version":
+			return exitCode(writeVersion(stdout, goVersion), stderr)
+		case "completion":
+			if len(args) != 3 {
+				return exitCode(usageErrorf("usage: hello-go completion bash|zsh|fish"), stderr)
+			}
+			return exitCode(GenerateCompletion(args[2], stdout), stderr)
+		case "serve":
+			return runServe(ctx, args[2:], stderr, env)
+		case "check":
+			return exitCode(checkCommand(args[2:], stderr, env), stderr)
+		case "locales":
+			return exitCode(localesCommand(args[2:], stdout, stderr), stderr)
+		case "repl":
+			args = append([]string{args[0], "-interactive"}, args[2:]...)
+		}
+	}
+	return exitCode(greetCommand(ctx, args, stdin, stdout, stderr, env, goVersion, logger), stderr)
+}
+
+// greetCommand implements the default command of run, greeting the names
+// given by args or stdin.
+func greetCommand(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer, env func(string) string, goVersion func() string, logger *slog.Logger) (err error) {
+	fs := flag.NewFlagSet("hello-go", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), "Usage: hello-go [flags] [name ...]\n       hello-go version\n       hello-go completion bash|zsh|fish\n       hello-go serve [-addr address] [-grpc-addr address] [-rate-limit N] [-config file] [-log stderr|json|syslog]\n       hello-go repl [flags]\n       hello-go check [-config file] [-locale-file file]\n       hello-go locales [-format text|json] [-locale-file file]\n\nFlags:\n")
+		fs.PrintDefaults()
+		fmt.Fprint(fs.Output(), usageFooter)
+	}
+	lang := fs.String("lang", string(greet.DefaultLocale), "greeting language: "+joinLocales(", "))
+	format := fs.String("format", formatText, "output format: text, json or csv")
+	style := fs.String("greeting", styleHello, "greeting style: hello or timeofday (English only)")
+	color := fs.String("color", colorAuto, "colorize names: auto, always or never (auto honors NO_COLOR)")
+	localeFile := fs.String("locale-file", "", "JSON `file` mapping extra or overriding locales to greeting templates such as \"Hi, %s! %s\"")
+	configPath := fs.String("config", defaultConfigPath(env), "path to the TOML config file")
+	emoji := fs.String("emoji", "", "emoji ending each greeting: a single emoji, or none (default: the language's own, "+greet.DefaultEmoji+" in English)")
+	raw := fs.Bool("raw", false, "print names as given, without removing control characters and escape sequences")
+	normalize := fs.Bool("normalize", false, "convert names to Unicode NFC so equivalent spellings print identically")
+	titleCase := fs.Bool("title-case", false, "capitalize the first letter of each name")
+	countOnly := fs.Bool("count-only", false, "print only the number of greetings instead of the greetings")
+	concurrency := fs.Int("concurrency", 1, "greet names on `N` goroutines, keeping input order (0 means one per CPU)")
+	word := fs.String("word", "", "replace the greeting word, e.g. Welcome, keeping the language's punctuation")
+	noBidi := fs.Bool("no-bidi", false, "do not wrap names in Unicode bidi isolates in right-to-left languages")
+	tmplText := fs.String("template", "", "Go text/template for each greeting, with fields .Name, .Locale, .GoVersion and .Time and funcs upper and title")
+	wrap := fs.Int("wrap", 0, "wrap text greetings at `N` columns without splitting names or words (0 means no wrapping; defaults to the terminal width with -group)")
+	dedupe := fs.Bool("dedupe", false, "greet each distinct name only once, comparing normalized names with -normalize (remembers every name seen)")
+	random := fs.Bool("random", false, "greet a random name from a built-in list or -names-file; -repeat picks several")
+	seed := fs.Uint64("seed", 0, "seed for -random, to reproduce its picks (default: a random seed)")
+	namesFile := fs.String("names-file", "", "`file` of names, one per line, for -random to pick from")
+	maxNameLength := fs.Int("max-name-length", 0, "limit names to `N` characters, counting each emoji once (0 means unlimited)")
+	onTooLong := fs.String("on-too-long", string(greet.PolicyTruncate), "what to do with names over -max-name-length: truncate, reject or skip")
+	group := fs.Bool("group", false, "greet all names together in one greeting")
+	interactive := fs.Bool("interactive", false, "greet each line typed at a prompt; :lang switches language, :quit exits")
+	showGoVersion := fs.Bool("show-go-version", true, "report the Go version: the last line of text output and a field of json and csv output")
+	explain := fs.Bool("explain", false, "print the resolved settings and where each came from instead of greeting")
+	outputPath := fs.String("output", "", "write the greetings to `file`, creating or truncating it, instead of stdout")
+	verbose := fs.Bool("verbose", false, "log debug details to stderr")
+	repeat := fs.Int("repeat", 1, "greet each name `N` times, all repeats of a name before the next name")
+	if err := fs.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return &exitError{code: exitUsage}
+	}
+	minLevel := slog.LevelWarn
+	if *verbose {
+		minLevel = slog.LevelDebug
+	}
+	logger = slog.New(minLevelHandler{minLevel, logger.Handler()})
+
+	// openOutput points stdout at the -output file, if any. It is called
+	// only once the settings are validated, so that a failed run leaves an
+	// existing file alone.
+	var finishOutput func() error
+	defer func() {
+		if finishOutput == nil {
+			return
+		}
+		if ferr := finishOutput(); ferr != nil && err == nil {
+			err = ferr
+		}
+	}()
+	openOutput := func() error {
+		if *outputPath == "" {
+			return nil
+		}
+		w, finish, err := createOutput(*outputPath)
+		if err != nil {
+			return err
+		}
+		stdout, finishOutput = w, finish
+		return nil
+	}
+
+	var cfg Config
+	if *configPath != "" {
+		var err error
+		if cfg, err = LoadConfig(*configPath); err != nil {
+			return &exitError{exitIO, err}
+		}
+		logger.Debug("loaded config", "path", *configPath, "empty", cfg == Config{})
+	} else {
+		logger.Debug("no config file location")
+	}
+	explicit := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	var resolved ResolvedConfig
+	// resolve applies the precedence documented in usageFooter to the
+	// setting name whose flag value is p, recording where the value came
+	// from in resolved.
+	resolve := func(name string, p *string, flagName, envKey, cfgValue string) {
+		s := Setting{Name: name, Source: SourceDefault}
+		if flagName != "" && explicit[flagName] {
+			s.Source, s.From = SourceFlag, "-"+flagName
+		} else if v := env(envKey); envKey != "" && v != "" {
+			*p = v
+			s.Source, s.From = SourceEnv, "$"+envKey
+		} else if cfgValue != "" {
+			*p = cfgValue
+			s.Source, s.From = SourceConfig, *configPath
+		}
+		s.Value = *p
+		resolved.Settings = append(resolved.Settings, s)
+	}
+	resolve("lang", lang, "lang", "HELLO_LANG", cfg.Lang)
+	resolve("greeting", style, "greeting", "HELLO_GREETING", cfg.Greeting)
+	resolve("word", word, "word", "", cfg.Word)
+	defaultName := greet.DefaultName
+	resolve("name", &defaultName, "", "HELLO_NAME", cfg.Name)
+	logger.Debug("resolved settings", "locale", *lang, "greeting", *style, "defaultName", defaultName)
+	if *explain {
+		resolved.addFlags(fs, explicit)
+		return resolved.write(stdout, *format)
+	}
+
+	if *wrap < 0 {
+		return usageErrorf("-wrap must not be negative, got %d", *wrap)
+	}
+	if *repeat < 1 {
+		return usageErrorf("-repeat must be at least 1, got %d", *repeat)
+	}
+	if *maxNameLength < 0 {
+		return usageErrorf("-max-name-length must not be negative, got %d", *maxNameLength)
+	}
+	var limit *nameLimit
+	switch p := greet.Policy(*onTooLong); p {
+	case greet.PolicyTruncate, greet.PolicyReject, greet.PolicySkip:
+		if *maxNameLength > 0 {
+			limit = &nameLimit{*maxNameLength, p}
+		}
+	default:
+		return usageErrorf("unknown -on-too-long policy %q (want truncate, reject or skip)", *onTooLong)
+	}
+	switch *format {
+	case formatText, formatJSON:
+	case formatCSV:
+		if *countOnly {
+			return usageErrorf("-count-only cannot be combined with -format=csv")
+		}
+	default:
+		return usageErrorf("unknown format %q (want text, json or csv)", *format)
+	}
+	var custom map[greet.Locale]string
+	if *localeFile != "" {
+		var err error
+		if custom, err = LoadLocaleFile(*localeFile); err != nil {
+			return &exitError{exitIO, err}
+		}
+		logger.Debug("loaded locale file", "path", *localeFile, "locales", len(custom))
+	}
+	locale := greet.Locale(*lang)
+	opts := []greet.Option{
+		greet.WithTemplates(custom),
+		greet.WithLocale(locale), greet.WithSanitize(!*raw),
+		greet.WithNormalize(*normalize), greet.WithTitleCase(*titleCase),
+		greet.WithBidi(!*noBidi), greet.WithWord(*word),
+	}
+	if *showGoVersion {
+		opts = append(opts, greet.WithGoVersion(goVersion))
+	} else {
+		opts = append(opts, greet.WithGoVersion(nil))
+	}
+	if *emoji == emojiNone {
+		opts = append(opts, greet.WithEmoji(""))
+	} else if explicit["emoji"] {
+		opts = append(opts, greet.WithEmoji(*emoji))
+	}
+	// The -output file is not open yet, but it is never a terminal.
+	dest := stdout
+	if *outputPath != "" {
+		dest = nil
+	}
+	// Only a group greeting is long enough to need wrapping by default.
+	width := *wrap
+	if !explicit["wrap"] && *group {
+		width = terminalWidth(dest)
+	}
+	if *format == formatText {
+		opts = append(opts, greet.WithWrap(width))
+	}
+	switch *color {
+	case colorAuto, colorAlways, colorNever:
+		if *format == formatText && useColor(*color, dest, env("NO_COLOR") != "") {
+			opts = append(opts, greet.WithNameDecorator(func(name string) string {
+				return ansiName + name + ansiReset
+			}))
+		}
+	default:
+		return usageErrorf("unknown color mode %q (want auto, always or never)", *color)
+	}
+	switch *style {
+	case styleHello:
+	case styleTimeOfDay:
+		opts = append(opts, greet.WithTimeOfDay(time.Now))
+	default:
+		return usageErrorf("unknown greeting %q (want hello or timeofday)", *style)
+	}
+	g, err := greet.New(opts...)
+	if errors.Is(err, greet.ErrUnsupportedLocale) {
+		return &exitError{exitLocale, err}
+	}
+	if err != nil {
+		return &exitError{exitUsage, err}
+	}
+
+	if *interactive {
+		if err := openOutput(); err != nil {
+			return err
+		}
+		return interact(ctx, stdin, stdout, g)
+	}
+
+	var tmpl *template.Template
+	if *tmplText != "" {
+		if tmpl, err = parseTemplate(*tmplText); err != nil {
+			return usageErrorf("invalid -template: %w", err)
+		}
+	}
+	names := fs.Args()
+	repeatEach := *repeat
+	if *random {
+		if len(names) > 0 {
+			return usageErrorf("-random cannot be combined with name arguments")
+		}
+		s := rand.Uint64()
+		if explicit["seed"] {
+			s = *seed
+		}
+		logger.Debug("picking random names", "seed", s)
+		if names, err = randomNames(newRand(s), *namesFile, *repeat); err != nil {
+			return err
+		}
+		repeatEach = 1
+	} else if *namesFile != "" {
+		return usageErrorf("-names-file requires -random")
+	}
+
+	if err := openOutput(); err != nil {
+		return err
+	}
+	out := newOutput(stdout, *format, repeatEach, *countOnly)
+	out.goVersion = g.GoVersion()
+	if tmpl != nil {
+		out.render = templateRenderer(tmpl, g.Locale(), time.Now)
+		out.wrap = width
+	}
+	if len(names) == 0 && isTerminal(stdin) {
+		names = []string{defaultName}
+	}
+	var seen *nameSet
+	if *dedupe {
+		seen = newNameSet(*normalize)
+	}
+	fromStdin := len(names) == 0
+	if fromStdin && *concurrency == 1 && !*group {
+		return streamStdin(ctx, stdin, out, g, limit, seen, logger)
+	}
+	if fromStdin {
+		if err := readStdin(ctx, stdin, &names, logger); err != nil {
+			return err
+		}
+	}
+	if names, err = limit.filter(names); err != nil {
+		return err
+	}
+	names = seen.filter(names)
+
+	var results []greet.Result
+	if *group {
+		if len(names) == 0 {
+			names = []string{defaultName}
+		}
+		results = []greet.Result{g.Group(names)}
+	} else if *concurrency == 1 {
+		results = g.GreetAll(names)
+	} else if results, err = resultsConcurrent(ctx, names, *concurrency, g); err != nil {
+		return &exitError{code: exitInterrupted}
+	}
+	for _, r := range results {
+		if err := out.write(r); err != nil {
+			return err
+		}
+	}
+	return out.close()
+}
+
+// streamStdin greets the names read from stdin as they arrive, with the
+// stream of GreetStream. Names are first limited by limit, then skipped if
+// already in seen; both may be nil.
+func streamStdin(ctx context.Context, stdin io.Reader, out *output, g *greet.Greeter, limit *nameLimit, seen *nameSet, logger *slog.Logger) error {
+	start := time.Now()
+	read := 0
+	err := greetStream(ctx, stdin, out, g, func(name string) (string, bool, error) {
+		read++
+		name, ok, err := limit.apply(name)
+		if err != nil || !ok {
+			return name, false, err
+		}
+		return name, seen.first(name), nil
+	})
+	logger.Debug("read names from stdin", "count", read, "duration", time.Since(start))
+	if err != nil && ctx.Err() != nil {
+		return &exitError{code: exitInterrupted}
+	}
+	return err
+}
+
+// readStdin appends the names read from stdin to names.
+func readStdin(ctx context.Context, stdin io.Reader, names *[]string, logger *slog.Logger) error {
+	start := time.Now()
+	err := forEachName(ctx, stdin, func() error { return nil }, func(name string) error {
+		*names = append(*names, name)
+		return nil
+	})
+	logger.Debug("read names from stdin", "count", len(*names), "duration", time.Since(start))
+	if err != nil && ctx.Err() != nil {
+		return &exitError{code: exitInterrupted}
+	}
+	return err
+}
+
+// interact runs RunREPL until it returns or ctx is canceled. The REPL blocks
+// reading stdin, so on cancellation it is abandoned rather than waited for.
+func interact(ctx context.Context, stdin io.Reader, stdout io.Writer, g *greet.Greeter) error {
+	done := make(chan error, 1)
+	go func() { done <- RunREPL(stdin, stdout, g) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return &exitError{code: exitInterrupted}
+	}
+}
+
+// joinLocales returns the supported locales separated by sep.
+func joinLocales(sep string) string {
+	var b strings.Builder
+	for i, l := range greet.SupportedLocales() {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(string(l))
+	}
+	return b.String()
+}
+
+// useColor reports whether names should be colorized for the given -color
+// mode. In auto mode color is used only when w is a terminal and NO_COLOR is
+// not set; NO_COLOR does not affect always.
+func useColor(mode string, w io.Writer, noColor bool) bool {
+	switch mode {
+	case colorAlways:
+		return true
+	case colorAuto:
+		return !noColor && w != nil && isTerminal(w)
+	}
+	return false
+}
+
+// terminalWidth returns the width in columns of the terminal w writes to, or
+// 0 if w is not a terminal; tests replace it.
+var terminalWidth = func(w io.Writer) int {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return 0
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// isTerminal reports whether v, a reader or writer, is an interactive
+// terminal. A nil value is treated as a terminal so that nothing is read from
+// it.
+func isTerminal(v any) bool {
+	if v == nil {
+		return true
+	}
+	f, ok := v.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
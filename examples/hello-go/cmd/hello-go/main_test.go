@@ -0,0 +1,474 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+func newGreeter(t *testing.T, opts ...greet.Option) *greet.Greeter {
+	t.Helper()
+	g, err := greet.New(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestRunUnsupportedLocale(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run(context.Background(), []string{"hello-go", "-lang=xx", "Alice"}, nil, &stdout, &stderr, noEnv, nil, nil); code != exitLocale {
+		t.Errorf("exit code = %d, want %d", code, exitLocale)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want empty", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), `unsupported locale "xx"`) {
+		t.Errorf("stderr = %q, want unsupported locale message", stderr.String())
+	}
+}
+
+func TestRun(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"hello-go", "-lang=es", "Ana"}, nil, &stdout, &stderr, noEnv, nil, nil)
+	if code != 0 {
+		t.Fatalf("exit code = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+	want := "¡Hola, Ana! 👋\nGo version: " + runtime.Version() + "\n"
+	if got := stdout.String(); got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("stderr = %q, want empty", stderr.String())
+	}
+}
+
+func TestRunBadFlag(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"hello-go", "-nope"}, nil, &stdout, &stderr, noEnv, nil, nil)
+	if code != 2 {
+		t.Errorf("exit code = %d, want 2", code)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want empty", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "flag provided but not defined: -nope") {
+		t.Errorf("stderr = %q, want flag error", stderr.String())
+	}
+}
+
+func TestRunUnknownFormat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run(context.Background(), []string{"hello-go", "-format=yaml"}, nil, &stdout, &stderr, noEnv, nil, nil); code != 2 {
+		t.Errorf("exit code = %d, want 2", code)
+	}
+	if !strings.Contains(stderr.String(), `unknown format "yaml"`) {
+		t.Errorf("stderr = %q, want unknown format message", stderr.String())
+	}
+}
+
+func TestRunStdin(t *testing.T) {
+	in := strings.NewReader("Alice\n\n  Bob  \n\t\nCarol")
+	var out bytes.Buffer
+	if code := run(context.Background(), []string{"hello-go", "-lang=fr"}, in, &out, io.Discard, noEnv, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0", code)
+	}
+	want := "Bonjour, Alice ! 👋\nBonjour, Bob ! 👋\nBonjour, Carol ! 👋\nGo version: " + runtime.Version() + "\n"
+	if got := out.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestRunArgsIgnoreStdin(t *testing.T) {
+	in := strings.NewReader("Bob\n")
+	var out bytes.Buffer
+	if code := run(context.Background(), []string{"hello-go", "Alice"}, in, &out, io.Discard, noEnv, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0", code)
+	}
+	if got := out.String(); !strings.HasPrefix(got, "Hello, Alice! 🐹\nGo version:") {
+		t.Errorf("output = %q, want only Alice greeted", got)
+	}
+}
+
+func TestRunNoStdin(t *testing.T) {
+	var out bytes.Buffer
+	if code := run(context.Background(), []string{"hello-go"}, nil, &out, io.Discard, noEnv, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0", code)
+	}
+	if got := out.String(); !strings.HasPrefix(got, "Hello, World! 🐹\n") {
+		t.Errorf("output = %q, want default greeting", got)
+	}
+}
+
+func TestRunTimeOfDay(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run(context.Background(), []string{"hello-go", "-greeting=timeofday", "Sam"}, nil, &stdout, &stderr, noEnv, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+	if got := stdout.String(); !strings.HasPrefix(got, "Good ") || !strings.Contains(got, ", Sam!\n") {
+		t.Errorf("stdout = %q, want a time-of-day greeting", got)
+	}
+}
+
+func TestRunUnknownGreeting(t *testing.T) {
+	var stderr bytes.Buffer
+	if code := run(context.Background(), []string{"hello-go", "-greeting=howdy"}, nil, io.Discard, &stderr, noEnv, nil, nil); code != 2 {
+		t.Errorf("exit code = %d, want 2", code)
+	}
+	if !strings.Contains(stderr.String(), `unknown greeting "howdy"`) {
+		t.Errorf("stderr = %q, want unknown greeting message", stderr.String())
+	}
+}
+
+func TestRunColor(t *testing.T) {
+	tests := []struct {
+		mode string
+		want bool
+	}{
+		{colorAuto, false},
+		{colorNever, false},
+		{colorAlways, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			var stdout bytes.Buffer
+			if code := run(context.Background(), []string{"hello-go", "-color=" + tt.mode, "Sam"}, nil, &stdout, io.Discard, noEnv, nil, nil); code != 0 {
+				t.Fatalf("exit code = %d, want 0", code)
+			}
+			got := strings.Contains(stdout.String(), "\x1b[")
+			if got != tt.want {
+				t.Errorf("stdout = %q, escape codes present = %v, want %v", stdout.String(), got, tt.want)
+			}
+			if tt.want && !strings.Contains(stdout.String(), "Hello, "+ansiName+"Sam"+ansiReset+"! 🐹") {
+				t.Errorf("stdout = %q, want colored name", stdout.String())
+			}
+		})
+	}
+}
+
+func TestRunColorJSON(t *testing.T) {
+	var stdout bytes.Buffer
+	if code := run(context.Background(), []string{"hello-go", "-color=always", "-format=json", "Sam"}, nil, &stdout, io.Discard, noEnv, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0", code)
+	}
+	if strings.Contains(stdout.String(), "\\u001b") {
+		t.Errorf("json output %q contains color escapes", stdout.String())
+	}
+}
+
+func TestUseColorNoColor(t *testing.T) {
+	if useColor(colorAuto, &bytes.Buffer{}, true) {
+		t.Error("auto mode with NO_COLOR enabled color")
+	}
+	if !useColor(colorAlways, &bytes.Buffer{}, true) {
+		t.Error("always mode with NO_COLOR disabled color")
+	}
+}
+
+func TestRunRepeat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	args := []string{"hello-go", "-repeat=3", "-format=json", "Alice", "Bob"}
+	if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	want := []string{"Alice", "Alice", "Alice", "Bob", "Bob", "Bob"}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d: %q", len(lines), len(want), lines)
+	}
+	for i, line := range lines {
+		var r greet.Result
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", line, err)
+		}
+		if r.Name != want[i] {
+			t.Errorf("line %d name = %q, want %q", i, r.Name, want[i])
+		}
+	}
+}
+
+func TestRunRepeatInvalid(t *testing.T) {
+	for _, n := range []string{"0", "-2"} {
+		var stdout, stderr bytes.Buffer
+		if code := run(context.Background(), []string{"hello-go", "-repeat=" + n, "Sam"}, nil, &stdout, &stderr, noEnv, nil, nil); code != 2 {
+			t.Errorf("-repeat=%s: exit code = %d, want 2", n, code)
+		}
+		if stdout.Len() != 0 {
+			t.Errorf("-repeat=%s: stdout = %q, want empty", n, stdout.String())
+		}
+		if !strings.Contains(stderr.String(), "-repeat must be at least 1") {
+			t.Errorf("-repeat=%s: stderr = %q", n, stderr.String())
+		}
+	}
+}
+
+func TestRunSanitize(t *testing.T) {
+	name := "\x1b[31mEve\x1b[0m\nrm -rf"
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"hello-go", "-color=never", name}, "Hello, Eve rm -rf! 🐹\n"},
+		{[]string{"hello-go", "-color=never", "-raw", name}, "Hello, " + name + "! 🐹\n"},
+	}
+	for _, tt := range tests {
+		var stdout bytes.Buffer
+		if code := run(context.Background(), tt.args, nil, &stdout, io.Discard, noEnv, nil, nil); code != 0 {
+			t.Fatalf("%q: exit code = %d, want 0", tt.args, code)
+		}
+		if got := stdout.String(); !strings.HasPrefix(got, tt.want) {
+			t.Errorf("%q: stdout = %q, want prefix %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestRunNormalizeTitleCase(t *testing.T) {
+	var composed, decomposed bytes.Buffer
+	for _, c := range []struct {
+		name string
+		out  *bytes.Buffer
+	}{{"\u00e9lodie", &composed}, {"e\u0301lodie", &decomposed}} {
+		if code := run(context.Background(), []string{"hello-go", "-normalize", "-title-case", c.name}, nil, c.out, io.Discard, noEnv, nil, nil); code != 0 {
+			t.Fatalf("exit code = %d, want 0", code)
+		}
+	}
+	if !bytes.Equal(composed.Bytes(), decomposed.Bytes()) {
+		t.Errorf("NFC output %q differs from NFD output %q", composed.String(), decomposed.String())
+	}
+	if !strings.HasPrefix(composed.String(), "Hello, \u00c9lodie! 🐹\n") {
+		t.Errorf("stdout = %q, want title-cased name", composed.String())
+	}
+}
+
+func TestRunEmoji(t *testing.T) {
+	tests := []struct {
+		emoji string
+		want  string
+	}{
+		{"none", "Hello, Sam!\n"},
+		{"🎉", "Hello, Sam! 🎉\n"},
+	}
+	for _, tt := range tests {
+		var stdout bytes.Buffer
+		if code := run(context.Background(), []string{"hello-go", "-emoji=" + tt.emoji, "Sam"}, nil, &stdout, io.Discard, noEnv, nil, nil); code != 0 {
+			t.Fatalf("-emoji=%s: exit code = %d, want 0", tt.emoji, code)
+		}
+		if got := stdout.String(); !strings.HasPrefix(got, tt.want) {
+			t.Errorf("-emoji=%s: stdout = %q, want prefix %q", tt.emoji, got, tt.want)
+		}
+	}
+
+	var stderr bytes.Buffer
+	if code := run(context.Background(), []string{"hello-go", "-emoji=🎉🐹", "Sam"}, nil, io.Discard, &stderr, noEnv, nil, nil); code == 0 {
+		t.Error("-emoji with two emoji: exit code = 0, want failure")
+	}
+	if !strings.Contains(stderr.String(), "must be a single character") {
+		t.Errorf("stderr = %q, want emoji error", stderr.String())
+	}
+}
+
+func TestRunCountOnly(t *testing.T) {
+	stdin := "Alice\n\nBob\n   \nCarol\n"
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-count-only"}, "3\n"},
+		{[]string{"-count-only", "-repeat=4"}, "12\n"},
+		{[]string{"-count-only", "-format=json"}, `{"count":3}` + "\n"},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		args := append([]string{"hello-go"}, tt.args...)
+		if code := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr, noEnv, nil, nil); code != 0 {
+			t.Fatalf("%q: exit code = %d, want 0 (stderr %q)", tt.args, code, stderr.String())
+		}
+		if got := stdout.String(); got != tt.want {
+			t.Errorf("%q: stdout = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestRunGroup(t *testing.T) {
+	tests := []struct {
+		args  []string
+		stdin string
+		want  string
+	}{
+		{[]string{"-group", "Alice", "Bob", "Carol"}, "", "Hello, Alice, Bob, and Carol! 🐹\n"},
+		{[]string{"-group", "-lang=fr"}, "Alice\nBob\n", "Bonjour, Alice et Bob ! 👋\n"},
+		{[]string{"-group", "-lang=de"}, "", "Hallo, World! 👋\n"},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		args := append([]string{"hello-go"}, tt.args...)
+		if code := run(context.Background(), args, strings.NewReader(tt.stdin), &stdout, &stderr, noEnv, nil, nil); code != 0 {
+			t.Fatalf("%q: exit code = %d, want 0 (stderr %q)", tt.args, code, stderr.String())
+		}
+		if want := tt.want + "Go version: " + runtime.Version() + "\n"; stdout.String() != want {
+			t.Errorf("%q: stdout = %q, want %q", tt.args, stdout.String(), want)
+		}
+	}
+}
+
+func TestRunBidi(t *testing.T) {
+	tests := []struct {
+		args    []string
+		isolate bool
+	}{
+		{[]string{"-lang=he", "Sam"}, true},
+		{[]string{"-lang=he", "-no-bidi", "Sam"}, false},
+		{[]string{"-lang=en", "Sam"}, false},
+	}
+	for _, tt := range tests {
+		var stdout bytes.Buffer
+		args := append([]string{"hello-go"}, tt.args...)
+		if code := run(context.Background(), args, nil, &stdout, io.Discard, noEnv, nil, nil); code != 0 {
+			t.Fatalf("%q: exit code = %d, want 0", tt.args, code)
+		}
+		out := stdout.String()
+		if got := strings.Contains(out, "\u2068Sam\u2069"); got != tt.isolate {
+			t.Errorf("%q: stdout = %q, isolated name = %v, want %v", tt.args, out, got, tt.isolate)
+		}
+		if !tt.isolate && strings.ContainsAny(out, "\u2068\u2069") {
+			t.Errorf("%q: stdout = %q contains bidi isolates", tt.args, out)
+		}
+	}
+}
+
+func TestRunWrap(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	args := []string{"hello-go", "-group", "-wrap=20", "Alice", "Bob", "Carol"}
+	if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+	want := "Hello, Alice, Bob,\nand Carol! 🐹\nGo version: " + runtime.Version() + "\n"
+	if got := stdout.String(); got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+
+	stdout.Reset()
+	args = []string{"hello-go", "-group", "-wrap=16", "Mary Ann Smith", "Bob"}
+	if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+	want = "Hello,\nMary Ann Smith\nand Bob! 🐹\nGo version: " + runtime.Version() + "\n"
+	if got := stdout.String(); got != want {
+		t.Errorf("multi-word name: stdout = %q, want %q", got, want)
+	}
+
+	stdout.Reset()
+	args = []string{"hello-go", "-group", "-wrap=20", "-format=json", "Alice", "Bob", "Carol"}
+	if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, nil, nil); code != 0 {
+		t.Fatalf("json: exit code = %d, want 0", code)
+	}
+	if strings.Contains(stdout.String(), `\n`) {
+		t.Errorf("json output %q is wrapped", stdout.String())
+	}
+}
+
+func TestRunWrapTerminal(t *testing.T) {
+	orig := terminalWidth
+	t.Cleanup(func() { terminalWidth = orig })
+	terminalWidth = func(io.Writer) int { return 20 }
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"hello-go", "Bartholomew Fitzgerald"}
+	if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+	want := "Hello, Bartholomew Fitzgerald! 🐹\nGo version: " + runtime.Version() + "\n"
+	if got := stdout.String(); got != want {
+		t.Errorf("single name: stdout = %q, want %q", got, want)
+	}
+
+	stdout.Reset()
+	args = []string{"hello-go", "-group", "Alice", "Bob", "Carol"}
+	if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+	want = "Hello, Alice, Bob,\nand Carol! 🐹\nGo version: " + runtime.Version() + "\n"
+	if got := stdout.String(); got != want {
+		t.Errorf("group: stdout = %q, want %q", got, want)
+	}
+
+	stdout.Reset()
+	args = []string{"hello-go", "-wrap=20", "Bartholomew Fitzgerald"}
+	if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+	if got := stdout.String(); !strings.HasPrefix(got, "Hello,\n") {
+		t.Errorf("explicit -wrap: stdout = %q, want a wrapped greeting", got)
+	}
+}
+
+func TestRunWrapNegative(t *testing.T) {
+	if code := run(context.Background(), []string{"hello-go", "-wrap=-1"}, nil, io.Discard, io.Discard, noEnv, nil, nil); code != exitUsage {
+		t.Errorf("exit code = %d, want %d", code, exitUsage)
+	}
+}
+
+func TestRunWord(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-word=Welcome", "Sam"}, "Welcome, Sam! 🐹\n"},
+		{[]string{"-word=Salut", "-lang=fr", "Sam"}, "Salut, Sam ! 👋\n"},
+		{[]string{"-word=Buenas", "-lang=es", "-emoji=none", "Ana"}, "¡Buenas, Ana!\n"},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		args := append([]string{"hello-go"}, tt.args...)
+		if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, nil, nil); code != 0 {
+			t.Fatalf("%q: exit code = %d, want 0 (stderr %q)", tt.args, code, stderr.String())
+		}
+		if got := stdout.String(); !strings.HasPrefix(got, tt.want) {
+			t.Errorf("%q: stdout = %q, want prefix %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestRunLocaleEmoji(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-lang=de", "Sam"}, "Hallo, Sam! 👋\n"},
+		{[]string{"-lang=ja", "Ken"}, "こんにちは、Kenさん！🙇\n"},
+		{[]string{"-lang=de", "-emoji=🐹", "Sam"}, "Hallo, Sam! 🐹\n"},
+		{[]string{"-lang=ja", "-emoji=🎉", "Ken"}, "こんにちは、Kenさん！🎉\n"},
+		{[]string{"-lang=de", "-emoji=none", "Sam"}, "Hallo, Sam!\n"},
+		{[]string{"-lang=de", "-emoji=", "Sam"}, "Hallo, Sam!\n"},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		args := append([]string{"hello-go"}, tt.args...)
+		if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, nil, nil); code != 0 {
+			t.Fatalf("%q: exit code = %d, want 0 (stderr %q)", tt.args, code, stderr.String())
+		}
+		if got := stdout.String(); !strings.HasPrefix(got, tt.want) {
+			t.Errorf("%q: stdout = %q, want prefix %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+// TestSkillManifestLocales keeps the lang input of SKILL.md in step with the
+// supported locales.
+func TestSkillManifestLocales(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "SKILL.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Greeting language (" + joinLocales(", ") + ")"; !strings.Contains(string(data), want) {
+		t.Errorf("SKILL.md does not describe lang as %q", want)
+	}
+}
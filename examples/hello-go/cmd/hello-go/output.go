@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+// output writes greetings in the format selected by -format. Writes are
+// buffered; call flush to push them out and close to finish the output.
+type output struct {
+	w         *bufio.Writer
+	enc       *json.Encoder
+	csv       *csv.Writer
+	format    string
+	repeat    int
+	countOnly bool
+	count     int
+	wrap      int    // rendered text greetings are wrapped at this width if positive
+	goVersion string // the Go version line is omitted if empty
+
+	// render, if set, replaces the greeting of each result before it is
+	// written. Greeters wrap their own greetings (greet.WithWrap), but
+	// rendered ones are wrapped by write.
+	render func(greet.Result) (string, error)
+}
+
+// csvHeader is the first record of csv output.
+var csvHeader = []string{"name", "greeting", "go_version"}
+
+// newOutput returns an output writing to w. Each greeting is written repeat
+// times; with countOnly the greetings are only counted. In csv format the
+// header record is written first.
+func newOutput(w io.Writer, format string, repeat int, countOnly bool) *output {
+	bw := bufio.NewWriter(w)
+	o := &output{
+		w:         bw,
+		enc:       json.NewEncoder(bw),
+		csv:       csv.NewWriter(bw),
+		format:    format,
+		repeat:    repeat,
+		countOnly: countOnly,
+	}
+	if format == formatCSV {
+		// Errors are kept by the csv.Writer and reported by flush.
+		o.csv.Write(csvHeader)
+	}
+	return o
+}
+
+// write emits r repeat times: as a line of text, as a Result object on its
+// own line (NDJSON) in json format, or as a record in csv format. Results are
+// encoded as they are written, with one encoder for the whole output, so
+// streaming stdin uses constant memory however many names it holds.
+func (o *output) write(r greet.Result) error {
+	o.count += o.repeat
+	if o.countOnly {
+		return nil
+	}
+	if o.render != nil {
+		s, err := o.render(r)
+		if err != nil {
+			return err
+		}
+		r.Greeting = s
+	}
+	for range o.repeat {
+		var err error
+		switch o.format {
+		case formatJSON:
+			err = o.enc.Encode(r)
+		case formatCSV:
+			err = o.csv.Write([]string{r.Name, r.Greeting, r.GoVersion})
+		default:
+			err = writeAll(o.w, greet.WrapGreeting(r.Text(), o.wrap)+"\n")
+		}
+		if err != nil {
+			return checkWrite(err)
+		}
+	}
+	return nil
+}
+
+// flush writes any buffered output.
+func (o *output) flush() error {
+	o.csv.Flush()
+	if err := o.csv.Error(); err != nil {
+		return checkWrite(err)
+	}
+	return checkWrite(o.w.Flush())
+}
+
+// close writes the trailer and flushes. The trailer is the number of
+// greetings with -count-only, either bare or as {"count":N} in json format,
+// and otherwise a Go version line in text format unless goVersion is empty.
+func (o *output) close() error {
+	var err error
+	switch {
+	case o.countOnly && o.format == formatJSON:
+		err = o.enc.Encode(struct {
+			Count int `json:"count"`
+		}{o.count})
+	case o.countOnly:
+		err = writeAll(o.w, strconv.Itoa(o.count)+"\n")
+	case o.format == formatText && o.goVersion != "":
+		err = writeAll(o.w, "Go version: "+o.goVersion+"\n")
+	}
+	if err != nil {
+		return checkWrite(err)
+	}
+	return o.flush()
+}
+
+// createOutput creates or truncates the file at path for -output. It returns
+// a buffered writer to the file and a function that flushes and closes it.
+// Both report failures as exitIO errors.
+func createOutput(path string) (io.Writer, func() error, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, &exitError{exitIO, err}
+	}
+	w := bufio.NewWriter(f)
+	finish := func() error {
+		err := w.Flush()
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return &exitError{exitIO, err}
+		}
+		return nil
+	}
+	return w, finish, nil
+}
+
+// writeAll writes s to w. Like every output path it reports write errors
+// through checkWrite.
+func writeAll(w io.Writer, s string) error {
+	_, err := io.WriteString(w, s)
+	return checkWrite(err)
+}
+
+// checkWrite maps a write error caused by the reader of stdout going away,
+// as in "hello-go ... | head", to a silent successful exit, following the
+// convention of Unix filters. Other errors are returned unchanged.
+func checkWrite(err error) error {
+	if isBrokenPipe(err) || errors.Is(err, io.ErrClosedPipe) {
+		return &exitError{code: exitOK}
+	}
+	return err
+}
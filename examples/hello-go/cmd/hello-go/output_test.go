@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+// writeResults writes the greetings of g for names through an output.
+func writeResults(w io.Writer, format string, g *greet.Greeter, names []string) error {
+	out := newOutput(w, format, 1, false)
+	out.goVersion = g.GoVersion()
+	for _, name := range names {
+		if err := out.write(g.Result(name)); err != nil {
+			return err
+		}
+	}
+	return out.close()
+}
+
+func TestOutputText(t *testing.T) {
+	var buf bytes.Buffer
+	names := []string{"Alice", "Bob", "Mary Jane"}
+	if err := writeResults(&buf, formatText, newGreeter(t), names); err != nil {
+		t.Fatalf("writeResults error: %v", err)
+	}
+	want := "Hello, Alice! 🐹\nHello, Bob! 🐹\nHello, Mary Jane! 🐹\nGo version: " + runtime.Version() + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestOutputJSON(t *testing.T) {
+	var buf bytes.Buffer
+	names := []string{"Alice", "Bob"}
+	if err := writeResults(&buf, formatJSON, newGreeter(t, greet.WithLocale(greet.German)), names); err != nil {
+		t.Fatalf("writeResults error: %v", err)
+	}
+	sc := bufio.NewScanner(&buf)
+	var got []greet.Result
+	for sc.Scan() {
+		var r greet.Result
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", sc.Text(), err)
+		}
+		got = append(got, r)
+	}
+	if len(got) != len(names) {
+		t.Fatalf("got %d results, want %d", len(got), len(names))
+	}
+	for i, r := range got {
+		if r.Name != names[i] {
+			t.Errorf("result %d name = %q, want %q", i, r.Name, names[i])
+		}
+		if want := "Hallo, " + names[i] + "! 👋"; r.Greeting != want {
+			t.Errorf("result %d greeting = %q, want %q", i, r.Greeting, want)
+		}
+		if r.GoVersion != runtime.Version() {
+			t.Errorf("result %d goVersion = %q, want %q", i, r.GoVersion, runtime.Version())
+		}
+	}
+	if strings.Contains(buf.String(), "Go version:") {
+		t.Error("json output contains text Go version line")
+	}
+}
+
+func TestOutputCSV(t *testing.T) {
+	var buf bytes.Buffer
+	names := []string{`Smith, "Jo"`, "Bob"}
+	if err := writeResults(&buf, formatCSV, newGreeter(t, greet.WithEmoji("")), names); err != nil {
+		t.Fatalf("writeResults error: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	want := [][]string{
+		csvHeader,
+		{`Smith, "Jo"`, `Hello, Smith, "Jo"!`, runtime.Version()},
+		{"Bob", "Hello, Bob!", runtime.Version()},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %q, want %q", records, want)
+	}
+}
+
+func TestOutputCSVEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeResults(&buf, formatCSV, newGreeter(t), nil); err != nil {
+		t.Fatalf("writeResults error: %v", err)
+	}
+	if got, want := buf.String(), "name,greeting,go_version\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestRunCSVCountOnly(t *testing.T) {
+	var stderr bytes.Buffer
+	code := run(context.Background(), []string{"hello-go", "-format=csv", "-count-only", "Sam"}, nil, io.Discard, &stderr, noEnv, nil, nil)
+	if code != exitUsage {
+		t.Errorf("exit code = %d, want %d", code, exitUsage)
+	}
+	if !strings.Contains(stderr.String(), "-count-only") {
+		t.Errorf("stderr = %q, want -count-only message", stderr.String())
+	}
+}
+
+func TestWriteAll(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeAll(&buf, "hi\n"); err != nil || buf.String() != "hi\n" {
+		t.Errorf("writeAll = %v, wrote %q", err, buf.String())
+	}
+	_, w := io.Pipe()
+	w.Close()
+	if code := exitCode(writeAll(w, "hi\n"), io.Discard); code != exitOK {
+		t.Errorf("writeAll to closed pipe: exit code = %d, want %d", code, exitOK)
+	}
+	err := errors.New("disk full")
+	if got := writeAll(failingWriter{err}, "hi\n"); got != err {
+		t.Errorf("writeAll error = %v, want %v", got, err)
+	}
+}
+
+type failingWriter struct{ err error }
+
+func (w failingWriter) Write([]byte) (int, error) { return 0, w.err }
+
+func TestRunOutputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "greetings.txt")
+	if err := os.WriteFile(path, []byte("old content that is longer than the greetings\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	args := []string{"hello-go", "-output", path, "-verbose", "Alice", "Bob"}
+	if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, fixedVersion("GOVERSION"), nil); code != exitOK {
+		t.Fatalf("exit code = %d, want %d (stderr %q)", code, exitOK, stderr.String())
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want empty", stdout.String())
+	}
+	if stderr.Len() == 0 {
+		t.Error("stderr is empty, want the verbose log")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "Hello, Alice! 🐹\nHello, Bob! 🐹\nGo version: GOVERSION\n"; got != want {
+		t.Errorf("file = %q, want %q", got, want)
+	}
+}
+
+func TestRunOutputFileFlushedOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "greetings.txt")
+	args := []string{"hello-go", "-output", path, "-max-name-length=5", "-on-too-long=reject"}
+	code := run(context.Background(), args, strings.NewReader("Ana\nMaximiliana\n"), io.Discard, io.Discard, noEnv, nil, nil)
+	if code != exitFailure {
+		t.Fatalf("exit code = %d, want %d", code, exitFailure)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "Hello, Ana! 🐹\n"; got != want {
+		t.Errorf("file = %q, want the greetings before the error, %q", got, want)
+	}
+}
+
+func TestRunOutputFileKeptOnInvalidRun(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{"usage error", []string{"-wrap=-1", "Sam"}, exitUsage},
+		{"locale error", []string{"-lang=xx", "Sam"}, exitLocale},
+		{"template error", []string{"-template={{.Nope}}", "Sam"}, exitUsage},
+		{"names file error", []string{"-random", "-names-file", "missing.txt"}, exitIO},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "notes.txt")
+			if err := os.WriteFile(path, []byte("keep me\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			args := append([]string{"hello-go", "-output", path}, tt.args...)
+			if code := run(context.Background(), args, nil, io.Discard, io.Discard, noEnv, nil, nil); code != tt.code {
+				t.Errorf("exit code = %d, want %d", code, tt.code)
+			}
+			if data, err := os.ReadFile(path); err != nil || string(data) != "keep me\n" {
+				t.Errorf("file = %q, %v; want it untouched", data, err)
+			}
+		})
+	}
+}
+
+func TestRunOutputFileExplain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("keep me\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var stdout bytes.Buffer
+	if code := run(context.Background(), []string{"hello-go", "-output", path, "-explain"}, nil, &stdout, io.Discard, noEnv, nil, nil); code != exitOK {
+		t.Fatalf("exit code = %d, want %d", code, exitOK)
+	}
+	if !strings.Contains(stdout.String(), "lang=en") {
+		t.Errorf("stdout = %q, want the explanation", stdout.String())
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "keep me\n" {
+		t.Errorf("file = %q, %v; want it untouched", data, err)
+	}
+}
+
+func TestRunOutputFileUnwritable(t *testing.T) {
+	dir := t.TempDir()
+	paths := []string{filepath.Join(dir, "missing", "greetings.txt")}
+	if os.Geteuid() != 0 {
+		// Permissions do not restrict root.
+		readOnly := filepath.Join(dir, "read-only")
+		if err := os.Mkdir(readOnly, 0o555); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, filepath.Join(readOnly, "greetings.txt"))
+	}
+	for _, path := range paths {
+		var stderr bytes.Buffer
+		if code := run(context.Background(), []string{"hello-go", "-output", path, "Sam"}, nil, io.Discard, &stderr, noEnv, nil, nil); code != exitIO {
+			t.Errorf("%s: exit code = %d, want %d", path, code, exitIO)
+		}
+		if !strings.Contains(stderr.String(), path) {
+			t.Errorf("%s: stderr = %q, want the path", path, stderr.String())
+		}
+	}
+}
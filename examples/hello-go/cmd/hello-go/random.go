@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strings"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+// demoNames are picked from by -random without -names-file.
+var demoNames = []string{
+	"Ada", "Alan", "Barbara", "Dennis", "Edsger", "Frances",
+	"Grace", "Ken", "Margaret", "Radia", "Rob", "Sophie",
+}
+
+// RandomName returns a name from names chosen with r, or greet.DefaultName
+// if names is empty.
+func RandomName(r *rand.Rand, names []string) string {
+	if len(names) == 0 {
+		return greet.DefaultName
+	}
+	return names[r.IntN(len(names))]
+}
+
+// newRand returns a random source that produces the same sequence for the
+// same seed, on every platform and Go release.
+func newRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+// randomNames returns n names picked with r from the names file at path, one
+// name per line, or from demoNames if path is "".
+func randomNames(r *rand.Rand, path string, n int) ([]string, error) {
+	pool := demoNames
+	if path != "" {
+		var err error
+		if pool, err = readNamesFile(path); err != nil {
+			return nil, err
+		}
+		if len(pool) == 0 {
+			return nil, usageErrorf("names file %s has no names", path)
+		}
+	}
+	names := make([]string, n)
+	for i := range names {
+		names[i] = RandomName(r, pool)
+	}
+	return names, nil
+}
+
+// readNamesFile returns the trimmed, non-blank lines of the file at path.
+func readNamesFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, &exitError{exitIO, fmt.Errorf("reading names: %w", err)}
+	}
+	defer f.Close()
+	var names []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if name := strings.TrimSpace(sc.Text()); name != "" {
+			names = append(names, name)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, &exitError{exitIO, fmt.Errorf("reading names from %s: %w", path, err)}
+	}
+	return names, nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+func pick(seed uint64, n int) []string {
+	r := newRand(seed)
+	names := make([]string, n)
+	for i := range names {
+		names[i] = RandomName(r, demoNames)
+	}
+	return names
+}
+
+func TestRandomName(t *testing.T) {
+	a, b := pick(42, 20), pick(42, 20)
+	if !slices.Equal(a, b) {
+		t.Errorf("seed 42 picked %q, then %q", a, b)
+	}
+	if c := pick(7, 20); slices.Equal(a, c) {
+		t.Errorf("seeds 42 and 7 both picked %q", a)
+	}
+	for _, name := range a {
+		if !slices.Contains(demoNames, name) {
+			t.Errorf("picked %q, not in demoNames", name)
+		}
+	}
+	if got := RandomName(newRand(1), nil); got != greet.DefaultName {
+		t.Errorf("RandomName(nil) = %q, want %q", got, greet.DefaultName)
+	}
+}
+
+func TestRunRandom(t *testing.T) {
+	greetings := func(args ...string) string {
+		t.Helper()
+		var stdout, stderr bytes.Buffer
+		args = append([]string{"hello-go", "-template={{.Name}}"}, args...)
+		if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, nil, nil); code != 0 {
+			t.Fatalf("%q: exit code = %d, want 0 (stderr %q)", args, code, stderr.String())
+		}
+		return stdout.String()
+	}
+	first := greetings("-random", "-seed=42", "-repeat=5")
+	if again := greetings("-random", "-seed=42", "-repeat=5"); again != first {
+		t.Errorf("-seed=42 greeted %q, then %q", first, again)
+	}
+	want := strings.Join(pick(42, 5), "\n") + "\n"
+	if !strings.HasPrefix(first, want) {
+		t.Errorf("-seed=42 greeted %q, want %q", first, want)
+	}
+
+	path := filepath.Join(t.TempDir(), "names.txt")
+	if err := os.WriteFile(path, []byte("\nZed\n  \n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := greetings("-random", "-names-file="+path, "-repeat=2"); !strings.HasPrefix(got, "Zed\nZed\n") {
+		t.Errorf("-names-file greeted %q, want Zed twice", got)
+	}
+}
+
+func TestRunRandomInvalid(t *testing.T) {
+	empty := filepath.Join(t.TempDir(), "empty.txt")
+	if err := os.WriteFile(empty, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{[]string{"-random", "Sam"}, exitUsage},
+		{[]string{"-names-file=" + empty}, exitUsage},
+		{[]string{"-random", "-names-file=" + empty}, exitUsage},
+		{[]string{"-random", "-names-file=" + filepath.Join(t.TempDir(), "missing.txt")}, exitIO},
+	}
+	for _, tt := range tests {
+		args := append([]string{"hello-go"}, tt.args...)
+		if code := run(context.Background(), args, nil, io.Discard, io.Discard, noEnv, nil, nil); code != tt.code {
+			t.Errorf("%q: exit code = %d, want %d", tt.args, code, tt.code)
+		}
+	}
+}
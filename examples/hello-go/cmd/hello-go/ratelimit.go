@@ -0,0 +1,103 @@
+package main
+
+import (
+	"container/list"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimitClients bounds the number of clients whose limiters are kept;
+// the least recently seen client is forgotten first.
+const rateLimitClients = 1024
+
+// rateLimiter limits the requests of each client IP with a token bucket of
+// its own. Only the most recently seen clients are remembered, so a client
+// that was evicted starts again with a full bucket.
+type rateLimiter struct {
+	limit rate.Limit
+	burst int
+	size  int
+	now   func() time.Time
+
+	mu      sync.Mutex
+	lru     *list.List               // of *clientLimiter, most recent first
+	clients map[string]*list.Element // by IP
+}
+
+// clientLimiter is the limiter of one client IP.
+type clientLimiter struct {
+	ip      string
+	limiter *rate.Limiter
+}
+
+// newRateLimiter returns a limiter allowing each client perSecond requests
+// per second, with bursts of as many, and keeping at most size clients. It
+// reads the time from now.
+func newRateLimiter(perSecond, size int, now func() time.Time) *rateLimiter {
+	return &rateLimiter{
+		limit:   rate.Limit(perSecond),
+		burst:   perSecond,
+		size:    size,
+		now:     now,
+		lru:     list.New(),
+		clients: map[string]*list.Element{},
+	}
+}
+
+// allow reports whether the client ip may make a request now and, if not,
+// how long it should wait before retrying.
+func (l *rateLimiter) allow(ip string) (bool, time.Duration) {
+	now := l.now()
+	r := l.client(ip).ReserveN(now, 1)
+	if d := r.DelayFrom(now); d > 0 {
+		r.CancelAt(now)
+		return false, d
+	}
+	return true, 0
+}
+
+// client returns the limiter of ip, creating it and evicting the least
+// recently seen client if needed.
+func (l *rateLimiter) client(ip string) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.clients[ip]; ok {
+		l.lru.MoveToFront(e)
+		return e.Value.(*clientLimiter).limiter
+	}
+	if l.lru.Len() >= l.size {
+		oldest := l.lru.Back()
+		l.lru.Remove(oldest)
+		delete(l.clients, oldest.Value.(*clientLimiter).ip)
+	}
+	c := &clientLimiter{ip, rate.NewLimiter(l.limit, l.burst)}
+	l.clients[ip] = l.lru.PushFront(c)
+	return c.limiter
+}
+
+// wrap rejects the requests of clients over the limit with 429 Too Many
+// Requests and a Retry-After header in whole seconds. A nil rateLimiter
+// returns h unchanged.
+func (l *rateLimiter) wrap(h http.Handler) http.Handler {
+	if l == nil {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+		if ok, wait := l.allow(ip); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+// fakeClock is a time source that only moves when advanced.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// getFrom sends GET target to h from the client address remote.
+func getFrom(h http.Handler, target, remote string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.RemoteAddr = remote
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandlerRateLimit(t *testing.T) {
+	clock := &fakeClock{time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)}
+	var cur atomic.Pointer[greet.Greeter]
+	cur.Store(newGreeter(t))
+	reg := prometheus.NewRegistry()
+	h := newHandler(&cur, reg, newRateLimiter(2, rateLimitClients, clock.now))
+
+	var ok, limited int
+	for range 5 {
+		switch rec := getFrom(h, "/greet", "192.0.2.1:1234"); rec.Code {
+		case http.StatusOK:
+			ok++
+		case http.StatusTooManyRequests:
+			limited++
+			if got := rec.Header().Get("Retry-After"); got != "1" {
+				t.Errorf("Retry-After = %q, want 1", got)
+			}
+		default:
+			t.Errorf("status = %d, want 200 or 429", rec.Code)
+		}
+	}
+	if ok != 2 || limited != 3 {
+		t.Errorf("got %d OK and %d limited responses, want 2 and 3", ok, limited)
+	}
+	if got := counterValue(t, reg, "hellogo_client_errors_total", nil); got != 3 {
+		t.Errorf("client errors = %v, want 3", got)
+	}
+
+	// Other clients have buckets of their own, and metrics are not limited.
+	if rec := getFrom(h, "/greet", "192.0.2.2:1234"); rec.Code != http.StatusOK {
+		t.Errorf("other client: status = %d, want 200", rec.Code)
+	}
+	if rec := getFrom(h, "/metrics", "192.0.2.1:1234"); rec.Code != http.StatusOK {
+		t.Errorf("metrics: status = %d, want 200", rec.Code)
+	}
+	// Requests from another port of the same IP share its bucket.
+	if rec := getFrom(h, "/greet", "192.0.2.1:5678"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("same IP, other port: status = %d, want 429", rec.Code)
+	}
+
+	clock.advance(500 * time.Millisecond)
+	if rec := getFrom(h, "/greet", "192.0.2.1:1234"); rec.Code != http.StatusOK {
+		t.Errorf("after refill: status = %d, want 200", rec.Code)
+	}
+}
+
+func TestRateLimiterEviction(t *testing.T) {
+	clock := &fakeClock{time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)}
+	l := newRateLimiter(1, 2, clock.now)
+	for _, ip := range []string{"a", "b"} {
+		if ok, _ := l.allow(ip); !ok {
+			t.Fatalf("first request of %s limited", ip)
+		}
+	}
+	if ok, wait := l.allow("a"); ok || wait != time.Second {
+		t.Errorf("second request of a = %t, %v; want false, 1s", ok, wait)
+	}
+	l.allow("c") // evicts b, the least recently seen client
+	if len(l.clients) != 2 {
+		t.Errorf("%d clients remembered, want 2", len(l.clients))
+	}
+	if ok, _ := l.allow("b"); !ok {
+		t.Error("evicted client b did not start with a full bucket")
+	}
+}
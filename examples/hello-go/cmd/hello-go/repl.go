@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+// replPrompt is written before each line RunREPL reads.
+const replPrompt = "> "
+
+// replHelp is printed for commands RunREPL does not know.
+const replHelp = "commands: :lang <locale> (switch language), :quit (exit)"
+
+// RunREPL reads lines from in and writes the greeting for each to out,
+// prompting before every line. A line starting with ':' is a command:
+// ":lang <locale>" switches the greeting language and ":quit" returns.
+// Blank lines are ignored. At the end of in, RunREPL writes a newline so the
+// shell prompt starts on a fresh line and returns nil.
+func RunREPL(in io.Reader, out io.Writer, g *greet.Greeter) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		if err := writeAll(out, replPrompt); err != nil {
+			return err
+		}
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return &exitError{exitIO, fmt.Errorf("reading input: %w", err)}
+			}
+			return writeAll(out, "\n")
+		}
+		line := strings.TrimSpace(scanner.Text())
+		var reply string
+		switch cmd, arg, _ := strings.Cut(line, " "); {
+		case line == "":
+			continue
+		case !strings.HasPrefix(line, ":"):
+			reply = g.Greet(line)
+		case cmd == ":quit":
+			return nil
+		case cmd == ":lang" && arg != "":
+			next, err := g.With(greet.WithLocale(greet.Locale(strings.TrimSpace(arg))))
+			if err != nil {
+				reply = err.Error()
+				break
+			}
+			g = next
+			reply = "language: " + string(g.Locale())
+		default:
+			reply = replHelp
+		}
+		if err := writeAll(out, reply+"\n"); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRunREPL(t *testing.T) {
+	session := "Sam\n:lang fr\nSam\n\n:lang xx\n:bogus\nAmélie\n:quit\nnever greeted\n"
+	var out bytes.Buffer
+	if err := RunREPL(strings.NewReader(session), &out, newGreeter(t)); err != nil {
+		t.Fatalf("RunREPL error: %v", err)
+	}
+	want := "> Hello, Sam! 🐹\n" +
+		"> language: fr\n" +
+		"> Bonjour, Sam ! 👋\n" +
+		"> " +
+		`> unsupported locale "xx"` + "\n" +
+		"> " + replHelp + "\n" +
+		"> Bonjour, Amélie ! 👋\n" +
+		"> "
+	if got := out.String(); got != want {
+		t.Errorf("session output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRunREPLEOF(t *testing.T) {
+	var out bytes.Buffer
+	if err := RunREPL(strings.NewReader("Sam"), &out, newGreeter(t)); err != nil {
+		t.Fatalf("RunREPL error: %v", err)
+	}
+	if got, want := out.String(), "> Hello, Sam! 🐹\n> \n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestRunReplCommand(t *testing.T) {
+	for _, args := range [][]string{
+		{"hello-go", "repl", "-lang=es", "-emoji=none"},
+		{"hello-go", "-interactive", "-lang=es", "-emoji=none"},
+	} {
+		var stdout bytes.Buffer
+		code := run(context.Background(), args, strings.NewReader("Ana\n"), &stdout, io.Discard, noEnv, nil, nil)
+		if code != 0 {
+			t.Fatalf("%q: exit code = %d", args, code)
+		}
+		if got, want := stdout.String(), "> ¡Hola, Ana!\n> \n"; got != want {
+			t.Errorf("%q: output = %q, want %q", args, got, want)
+		}
+	}
+}
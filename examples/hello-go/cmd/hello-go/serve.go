@@ -0,0 +1,317 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+// shutdownTimeout bounds how long the server waits for in-flight requests
+// after a shutdown signal.
+const shutdownTimeout = 5 * time.Second
+
+// NewHandler returns the HTTP API of the serve subcommand. GET /greet takes
+// the query parameters name (default World), lang (default: g's locale) and
+// format (text or json) and responds with the greeting. GET /metrics serves
+// Prometheus metrics about the API; see NewHandlerWithRegistry.
+func NewHandler(g *greet.Greeter) http.Handler {
+	return NewHandlerWithRegistry(g, prometheus.NewRegistry())
+}
+
+// NewHandlerWithRegistry is NewHandler with its metrics registered in reg
+// rather than a private registry. The metrics are:
+//
+//   - hellogo_greetings_total{locale}: greetings served, by locale
+//   - hellogo_request_duration_seconds: API request latency
+//   - hellogo_client_errors_total: API responses with a 4xx status
+//
+// The locale label only takes supported locales, since requests for other
+// locales are rejected before they are counted.
+func NewHandlerWithRegistry(g *greet.Greeter, reg *prometheus.Registry) http.Handler {
+	var cur atomic.Pointer[greet.Greeter]
+	cur.Store(g)
+	return newHandler(&cur, reg, nil)
+}
+
+// newHandler is NewHandlerWithRegistry with the greeter read from cur at the
+// start of each request, so that storing a new greeter in cur affects only
+// later requests. API requests are limited by lim, which may be nil; the
+// metrics endpoint is not limited.
+func newHandler(cur *atomic.Pointer[greet.Greeter], reg *prometheus.Registry, lim *rateLimiter) http.Handler {
+	m := newServeMetrics(reg)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /greet", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		g := cur.Load()
+		lg := g
+		if lang := q.Get("lang"); lang != "" {
+			var err error
+			if lg, err = g.With(greet.WithLocale(greet.Locale(lang))); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		res := lg.Result(q.Get("name"))
+		switch q.Get("format") {
+		case "", formatText:
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			fmt.Fprintln(w, res.Text())
+		case formatJSON:
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(res)
+		default:
+			http.Error(w, fmt.Sprintf("unknown format %q (want text or json)", q.Get("format")), http.StatusBadRequest)
+			return
+		}
+		m.greetings.WithLabelValues(string(lg.Locale())).Inc()
+	})
+
+	root := http.NewServeMux()
+	root.Handle("GET /metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	root.Handle("/", m.instrument(lim.wrap(mux)))
+	return root
+}
+
+// serveMetrics are the Prometheus collectors of NewHandlerWithRegistry.
+type serveMetrics struct {
+	greetings    *prometheus.CounterVec
+	duration     prometheus.Histogram
+	clientErrors prometheus.Counter
+}
+
+// newServeMetrics creates the serve metrics and registers them in reg. The
+// greeting counter is initialized for every supported locale so that all
+// series are present from the start.
+func newServeMetrics(reg *prometheus.Registry) *serveMetrics {
+	m := &serveMetrics{
+		greetings: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "hellogo_greetings_total",
+			Help: "Greetings served, by locale.",
+		}, []string{"locale"}),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "hellogo_request_duration_seconds",
+			Help:    "Latency of API requests.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		clientErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "hellogo_client_errors_total",
+			Help: "API responses with a 4xx status.",
+		}),
+	}
+	reg.MustRegister(m.greetings, m.duration, m.clientErrors)
+	for _, l := range greet.SupportedLocales() {
+		m.greetings.WithLabelValues(string(l))
+	}
+	return m
+}
+
+// instrument wraps h to observe the duration and status of each request.
+func (m *serveMetrics) instrument(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		h.ServeHTTP(sw, r)
+		m.duration.Observe(time.Since(start).Seconds())
+		if sw.status >= 400 && sw.status < 500 {
+			m.clientErrors.Inc()
+		}
+	})
+}
+
+// statusWriter records the status code written through it.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// serveGreeter returns the greeter that the serve subcommand uses for the
+// config file at path: its lang, greeting and word keys set the defaults of
+// the API. An empty path yields the built-in defaults.
+func serveGreeter(path string) (*greet.Greeter, error) {
+	var cfg Config
+	if path != "" {
+		var err error
+		if cfg, err = LoadConfig(path); err != nil {
+			return nil, err
+		}
+	}
+	var opts []greet.Option
+	if cfg.Lang != "" {
+		opts = append(opts, greet.WithLocale(greet.Locale(cfg.Lang)))
+	}
+	switch cfg.Greeting {
+	case "", styleHello:
+	case styleTimeOfDay:
+		opts = append(opts, greet.WithTimeOfDay(time.Now))
+	default:
+		return nil, fmt.Errorf("config: unknown greeting %q (want hello or timeofday)", cfg.Greeting)
+	}
+	opts = append(opts, greet.WithWord(cfg.Word))
+	return greet.New(opts...)
+}
+
+// reloadGreeter rereads the config file at path and stores its greeter in
+// cur. If the config is invalid, it logs why and leaves the previous greeter
+// in place.
+func reloadGreeter(path string, cur *atomic.Pointer[greet.Greeter], logger *slog.Logger) {
+	g, err := serveGreeter(path)
+	if err != nil {
+		logger.Error("reload failed, keeping previous config", "path", path, "err", err)
+		return
+	}
+	cur.Store(g)
+	logger.Info("reloaded config", "path", path)
+}
+
+// runServe implements the serve subcommand: it serves NewHandler on -addr
+// and NewGRPCServer on -grpc-addr until ctx is canceled or SIGINT or SIGTERM
+// arrives, then shuts down gracefully. SIGHUP rereads the config file
+// without dropping requests. Once the flags are valid, messages go to the
+// logger selected by -log.
+func runServe(ctx context.Context, args []string, stderr io.Writer, env func(string) string) int {
+	fs := flag.NewFlagSet("hello-go serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addr := fs.String("addr", ":8080", "address to serve the HTTP API on (empty for none)")
+	grpcAddr := fs.String("grpc-addr", "", "address to serve the gRPC API on (default: none)")
+	rateLimit := fs.Int("rate-limit", 0, "allow each client IP `N` HTTP API requests per second (0 means unlimited)")
+	configPath := fs.String("config", defaultConfigPath(env), "path to the TOML config file, reread on SIGHUP")
+	logKind := fs.String("log", logStderr, "where to log: stderr, json (JSON on stderr) or syslog")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "hello-go serve: unexpected arguments %q\n", fs.Args())
+		return exitUsage
+	}
+	if *rateLimit < 0 {
+		fmt.Fprintf(stderr, "hello-go serve: -rate-limit must not be negative, got %d\n", *rateLimit)
+		return exitUsage
+	}
+	if *addr == "" && *grpcAddr == "" {
+		fmt.Fprintln(stderr, "hello-go serve: -addr and -grpc-addr are both empty")
+		return exitUsage
+	}
+	switch *logKind {
+	case logStderr, logJSON, logSyslog:
+	default:
+		fmt.Fprintf(stderr, "hello-go serve: unknown -log %q (want stderr, json or syslog)\n", *logKind)
+		return exitUsage
+	}
+	h, err := newLogHandler(*logKind, stderr)
+	if err != nil {
+		fmt.Fprintf(stderr, "hello-go serve: %v\n", err)
+		return exitFailure
+	}
+	logger := slog.New(h)
+
+	g, err := serveGreeter(*configPath)
+	if err != nil {
+		logger.Error("loading config", "path", *configPath, "err", err)
+		// The exit codes are those of the greet command.
+		if errors.Is(err, greet.ErrUnsupportedLocale) {
+			return exitLocale
+		}
+		return exitIO
+	}
+	var cur atomic.Pointer[greet.Greeter]
+	cur.Store(g)
+	var lim *rateLimiter
+	if *rateLimit > 0 {
+		lim = newRateLimiter(*rateLimit, rateLimitClients, time.Now)
+	}
+	var srv *http.Server
+	if *addr != "" {
+		srv = &http.Server{
+			Addr:              *addr,
+			Handler:           newHandler(&cur, prometheus.NewRegistry(), lim),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+	}
+	var grpcSrv *grpc.Server
+	var grpcLis net.Listener
+	if *grpcAddr != "" {
+		if grpcLis, err = net.Listen("tcp", *grpcAddr); err != nil {
+			logger.Error("listening", "err", err)
+			return exitFailure
+		}
+		grpcSrv = newGRPCServer(&cur)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	errc := make(chan error, 2)
+	if srv != nil {
+		go func() { errc <- srv.ListenAndServe() }()
+		logger.Info("serving HTTP", "addr", *addr)
+	}
+	if grpcSrv != nil {
+		go func() { errc <- grpcSrv.Serve(grpcLis) }()
+		logger.Info("serving gRPC", "addr", grpcLis.Addr().String())
+	}
+
+	for done := false; !done; {
+		select {
+		case err := <-errc:
+			logger.Error("serving", "err", err)
+			return exitFailure
+		case <-hup:
+			reloadGreeter(*configPath, &cur, logger)
+		case <-ctx.Done():
+			done = true
+		}
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if grpcSrv != nil {
+		stopGRPC(shutdownCtx, grpcSrv)
+	}
+	if srv != nil {
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Error("shutdown", "err", err)
+			return exitFailure
+		}
+	}
+	return exitOK
+}
+
+// stopGRPC stops s gracefully, or forcibly once ctx is done.
+func stopGRPC(ctx context.Context, s *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.Stop()
+	}
+}
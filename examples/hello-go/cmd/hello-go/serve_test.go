@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+func get(t *testing.T, h http.Handler, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+func TestHandlerText(t *testing.T) {
+	h := NewHandler(newGreeter(t))
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{"/greet", "Hello, World! 🐹\n"},
+		{"/greet?name=Alice&lang=es", "¡Hola, Alice! 👋\n"},
+		{"/greet?name=Alice&format=text", "Hello, Alice! 🐹\n"},
+	}
+	for _, tt := range tests {
+		rec := get(t, h, tt.target)
+		if rec.Code != http.StatusOK {
+			t.Errorf("GET %s: status = %d, want 200", tt.target, rec.Code)
+		}
+		if got := rec.Body.String(); got != tt.want {
+			t.Errorf("GET %s: body = %q, want %q", tt.target, got, tt.want)
+		}
+		if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+			t.Errorf("GET %s: Content-Type = %q, want text/plain", tt.target, ct)
+		}
+	}
+}
+
+func TestHandlerJSON(t *testing.T) {
+	h := NewHandler(newGreeter(t))
+	rec := get(t, h, "/greet?name=Alice&lang=fr&format=json")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	var r greet.Result
+	if err := json.Unmarshal(rec.Body.Bytes(), &r); err != nil {
+		t.Fatalf("invalid JSON %q: %v", rec.Body.String(), err)
+	}
+	if r.Name != "Alice" || r.Greeting != "Bonjour, Alice ! 👋" || r.GoVersion == "" {
+		t.Errorf("result = %+v", r)
+	}
+}
+
+func TestHandlerBadRequest(t *testing.T) {
+	h := NewHandler(newGreeter(t))
+	for _, target := range []string{"/greet?lang=xx", "/greet?format=yaml"} {
+		rec := get(t, h, target)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("GET %s: status = %d, want 400", target, rec.Code)
+		}
+	}
+	if body := get(t, h, "/greet?lang=xx").Body.String(); !strings.Contains(body, `unsupported locale "xx"`) {
+		t.Errorf("body = %q, want unsupported locale message", body)
+	}
+}
+
+// counterValue returns the value of the counter named name in reg whose
+// labels include labels.
+func counterValue(t *testing.T, reg *prometheus.Registry, name string, labels map[string]string) float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather error: %v", err)
+	}
+	for _, mf := range families {
+		if mf.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, m := range mf.GetMetric() {
+			for _, lp := range m.GetLabel() {
+				if v, ok := labels[lp.GetName()]; ok && v != lp.GetValue() {
+					continue metrics
+				}
+			}
+			return m.GetCounter().GetValue()
+		}
+	}
+	t.Fatalf("no %s series with labels %v", name, labels)
+	return 0
+}
+
+func TestHandlerMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	h := NewHandlerWithRegistry(newGreeter(t), reg)
+	for _, target := range []string{
+		"/greet?name=Alice", "/greet?name=Bob", "/greet?lang=fr",
+		"/greet?lang=xx", "/greet?format=yaml", "/nope",
+	} {
+		get(t, h, target)
+	}
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   float64
+	}{
+		{"hellogo_greetings_total", map[string]string{"locale": "en"}, 2},
+		{"hellogo_greetings_total", map[string]string{"locale": "fr"}, 1},
+		{"hellogo_greetings_total", map[string]string{"locale": "de"}, 0},
+		{"hellogo_client_errors_total", nil, 3},
+	}
+	for _, tt := range tests {
+		if got := counterValue(t, reg, tt.name, tt.labels); got != tt.want {
+			t.Errorf("%s%v = %v, want %v", tt.name, tt.labels, got, tt.want)
+		}
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mf := range families {
+		switch mf.GetName() {
+		case "hellogo_greetings_total":
+			if n, want := len(mf.GetMetric()), len(greet.SupportedLocales()); n != want {
+				t.Errorf("hellogo_greetings_total has %d series, want one per supported locale (%d)", n, want)
+			}
+		case "hellogo_request_duration_seconds":
+			if n := mf.GetMetric()[0].GetHistogram().GetSampleCount(); n != 6 {
+				t.Errorf("hellogo_request_duration_seconds count = %d, want 6", n)
+			}
+		}
+	}
+
+	rec := get(t, h, "/metrics")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `hellogo_greetings_total{locale="en"} 2`) {
+		t.Errorf("GET /metrics: status %d, body %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandlerSwapGreeter(t *testing.T) {
+	var cur atomic.Pointer[greet.Greeter]
+	cur.Store(newGreeter(t))
+	h := newHandler(&cur, prometheus.NewRegistry(), nil)
+	if got, want := get(t, h, "/greet?name=Ana").Body.String(), "Hello, Ana! 🐹\n"; got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+
+	// Requests racing with the swap see either greeter, never a mix.
+	fr := newGreeter(t, greet.WithLocale(greet.French))
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				switch body := get(t, h, "/greet?name=Ana").Body.String(); body {
+				case "Hello, Ana! 🐹\n", "Bonjour, Ana ! 👋\n":
+				default:
+					t.Errorf("body = %q during swap", body)
+				}
+			}
+		}()
+	}
+	cur.Store(fr)
+	wg.Wait()
+
+	if got, want := get(t, h, "/greet?name=Ana").Body.String(), "Bonjour, Ana ! 👋\n"; got != want {
+		t.Errorf("body after swap = %q, want %q", got, want)
+	}
+	if got, want := get(t, h, "/greet?name=Ana&lang=de").Body.String(), "Hallo, Ana! 👋\n"; got != want {
+		t.Errorf("lang after swap: body = %q, want %q", got, want)
+	}
+}
+
+func TestRunServeBadConfig(t *testing.T) {
+	tests := []struct {
+		path string
+		code int
+	}{
+		{t.TempDir(), exitIO}, // a directory cannot be read as a config file
+		{writeConfig(t, "lang = 42\n"), exitIO},
+		{writeConfig(t, "lang = \"xx\"\n"), exitLocale},
+	}
+	for _, tt := range tests {
+		var stderr bytes.Buffer
+		if code := runServe(context.Background(), []string{"-addr=127.0.0.1:0", "-config", tt.path}, &stderr, noEnv); code != tt.code {
+			t.Errorf("%s: exit code = %d, want %d", tt.path, code, tt.code)
+		}
+		if !strings.Contains(stderr.String(), "loading config") {
+			t.Errorf("%s: stderr = %q, want the config error", tt.path, stderr.String())
+		}
+	}
+}
+
+func TestReloadGreeter(t *testing.T) {
+	path := writeConfig(t, "lang = \"es\"\nword = \"Buenas\"\n")
+	var cur atomic.Pointer[greet.Greeter]
+	cur.Store(newGreeter(t))
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	reloadGreeter(path, &cur, logger)
+	if got, want := cur.Load().Greet("Ana"), "¡Buenas, Ana! 👋"; got != want {
+		t.Errorf("after reload: Greet = %q, want %q", got, want)
+	}
+
+	for _, content := range []string{"lang = \"xx\"\n", "greeting = \"hi\"\n", "lang = 42\n", "word = \"Hey\"\ngreeting = \"timeofday\"\n"} {
+		prev := cur.Load()
+		logs.Reset()
+		reloadGreeter(writeConfig(t, content), &cur, logger)
+		if cur.Load() != prev {
+			t.Errorf("reload of %q replaced the greeter", content)
+		}
+		if !strings.Contains(logs.String(), "level=ERROR msg=\"reload failed, keeping previous config\"") {
+			t.Errorf("reload of %q: logs = %q, want failure message", content, logs.String())
+		}
+	}
+}
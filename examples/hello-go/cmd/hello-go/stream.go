@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+// GreetStream writes the greeting built by g for each non-blank line of in
+// to out, one per line. Output is buffered and flushed whenever in has no
+// more input ready, and before returning. Once ctx is canceled GreetStream
+// returns ctx.Err() promptly, even while a read from in is blocked.
+func GreetStream(ctx context.Context, in io.Reader, out io.Writer, g *greet.Greeter) error {
+	return greetStream(ctx, in, newOutput(out, formatText, 1, false), g, nil)
+}
+
+// greetStream is GreetStream writing the results through out, which it
+// closes at the end of in and flushes on error. Each name is first passed to
+// keep, if not nil, which returns the name to greet and whether to greet it,
+// or an error that ends the stream.
+func greetStream(ctx context.Context, in io.Reader, out *output, g *greet.Greeter, keep func(name string) (string, bool, error)) error {
+	err := forEachName(ctx, in, out.flush, func(name string) error {
+		if keep != nil {
+			var ok bool
+			var err error
+			if name, ok, err = keep(name); err != nil || !ok {
+				return err
+			}
+		}
+		return out.write(g.Result(name))
+	})
+	if err != nil {
+		out.flush()
+		return err
+	}
+	return out.close()
+}
+
+// forEachName calls fn with each trimmed, non-blank line of in. It calls idle
+// before waiting for input that is not ready yet, so that buffered output can
+// be flushed. It returns ctx.Err() as soon as ctx is canceled; a read that is
+// blocked at that point is abandoned. Read errors are returned as exitIO
+// errors.
+func forEachName(ctx context.Context, in io.Reader, idle func() error, fn func(name string) error) error {
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		sc := bufio.NewScanner(in)
+		for sc.Scan() {
+			select {
+			case lines <- sc.Text():
+			case <-done:
+				return
+			}
+		}
+		readErr <- sc.Err()
+		close(lines)
+	}()
+
+	for {
+		var line string
+		var ok bool
+		select {
+		case line, ok = <-lines:
+		default:
+			if err := idle(); err != nil {
+				return err
+			}
+			select {
+			case line, ok = <-lines:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if !ok {
+			if err := <-readErr; err != nil {
+				return &exitError{exitIO, fmt.Errorf("reading names: %w", err)}
+			}
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if name := strings.TrimSpace(line); name != "" {
+			if err := fn(name); err != nil {
+				return err
+			}
+		}
+	}
+}
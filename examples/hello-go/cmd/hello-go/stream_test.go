@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+// notifyWriter collects writes and signals each one on written.
+type notifyWriter struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	written chan struct{}
+}
+
+func (w *notifyWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	n, err := w.buf.Write(p)
+	select {
+	case w.written <- struct{}{}:
+	default:
+	}
+	return n, err
+}
+
+func (w *notifyWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestGreetStream(t *testing.T) {
+	var out bytes.Buffer
+	in := strings.NewReader("Alice\n\n  Bob\t\n")
+	if err := GreetStream(context.Background(), in, &out, newGreeter(t)); err != nil {
+		t.Fatalf("GreetStream error: %v", err)
+	}
+	if got, want := out.String(), "Hello, Alice! 🐹\nHello, Bob! 🐹\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestGreetStreamReadError(t *testing.T) {
+	in := io.MultiReader(strings.NewReader("Alice\n"), iotest.ErrReader(errors.New("disk on fire")))
+	var out bytes.Buffer
+	err := GreetStream(context.Background(), in, &out, newGreeter(t))
+	if err == nil || !strings.Contains(err.Error(), "disk on fire") {
+		t.Errorf("GreetStream error = %v, want read error", err)
+	}
+	if got, want := out.String(), "Hello, Alice! 🐹\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestGreetStreamCancel(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	out := &notifyWriter{written: make(chan struct{}, 1)}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errc := make(chan error, 1)
+	go func() { errc <- GreetStream(ctx, pr, out, newGreeter(t)) }()
+	if _, err := io.WriteString(pw, "Alice\nBob\n"); err != nil {
+		t.Fatal(err)
+	}
+	timeout := time.After(5 * time.Second)
+	for !strings.Contains(out.String(), "Bob") {
+		select {
+		case <-out.written:
+		case <-timeout:
+			t.Fatalf("greetings not flushed, output %q", out.String())
+		}
+	}
+
+	// The reader is now blocked waiting for the next line.
+	cancel()
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("GreetStream error = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("GreetStream did not return after cancel")
+	}
+	if got, want := out.String(), "Hello, Alice! 🐹\nHello, Bob! 🐹\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestRunInterrupted(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var stdout, stderr bytes.Buffer
+	if code := run(ctx, []string{"hello-go"}, pr, &stdout, &stderr, noEnv, nil, nil); code != exitInterrupted {
+		t.Errorf("exit code = %d, want %d", code, exitInterrupted)
+	}
+	if stdout.Len() != 0 || stderr.Len() != 0 {
+		t.Errorf("stdout = %q, stderr = %q; want both empty", stdout.String(), stderr.String())
+	}
+}
+
+func TestRunInterruptedMidStream(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	out := &notifyWriter{written: make(chan struct{}, 1)}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan int, 1)
+	go func() {
+		done <- run(ctx, []string{"hello-go", "-dedupe"}, pr, out, io.Discard, noEnv, nil, nil)
+	}()
+	if _, err := io.WriteString(pw, "Alice\nAlice\nBob\n"); err != nil {
+		t.Fatal(err)
+	}
+	timeout := time.After(5 * time.Second)
+	for !strings.Contains(out.String(), "Bob") {
+		select {
+		case <-out.written:
+		case <-timeout:
+			t.Fatalf("greetings not flushed, output %q", out.String())
+		}
+	}
+
+	cancel()
+	select {
+	case code := <-done:
+		if code != exitInterrupted {
+			t.Errorf("exit code = %d, want %d", code, exitInterrupted)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("run did not return after cancel")
+	}
+	// The greetings written so far are kept, without the Go version trailer.
+	if got, want := out.String(), "Hello, Alice! 🐹\nHello, Bob! 🐹\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestRunJSONStreams(t *testing.T) {
+	pr, pw := io.Pipe()
+	out := &notifyWriter{written: make(chan struct{}, 1)}
+	done := make(chan int, 1)
+	go func() {
+		done <- run(context.Background(), []string{"hello-go", "-format=json"}, pr, out, io.Discard, noEnv, nil, nil)
+	}()
+
+	// Each result is written before the next line is read.
+	timeout := time.After(5 * time.Second)
+	for _, name := range []string{"Alice", "Bob"} {
+		if _, err := io.WriteString(pw, name+"\n"); err != nil {
+			t.Fatal(err)
+		}
+		for !strings.Contains(out.String(), `"name":"`+name+`"`) {
+			select {
+			case <-out.written:
+			case <-timeout:
+				t.Fatalf("result for %s not written before more input, output %q", name, out.String())
+			}
+		}
+	}
+	pw.Close()
+	if code := <-done; code != 0 {
+		t.Fatalf("exit code = %d, want 0", code)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), out.String())
+	}
+	for _, line := range lines {
+		var r greet.Result
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Errorf("invalid NDJSON line %q: %v", line, err)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+// templateData is the value a -template is executed with.
+type templateData struct {
+	Name      string
+	Locale    greet.Locale
+	GoVersion string
+	Time      time.Time
+}
+
+// templateFuncs are the functions available to a -template.
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"title": func(s string) string { return cases.Title(language.Und).String(s) },
+}
+
+// parseTemplate parses text as a -template. Besides parsing, it executes the
+// template once on sample data so that references to unknown fields are
+// reported before any name is greeted.
+func parseTemplate(text string) (*template.Template, error) {
+	t, err := template.New("greeting").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	sample := templateData{greet.DefaultName, greet.DefaultLocale, "go", time.Now()}
+	if err := t.Execute(io.Discard, sample); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// templateRenderer returns a function that renders a result with t, taking
+// the locale from locale and the time from now.
+func templateRenderer(t *template.Template, locale greet.Locale, now func() time.Time) func(greet.Result) (string, error) {
+	return func(r greet.Result) (string, error) {
+		var b strings.Builder
+		err := t.Execute(&b, templateData{r.Name, locale, r.GoVersion, now()})
+		return b.String(), err
+	}
+}
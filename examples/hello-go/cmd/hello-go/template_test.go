@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/while-basic/enact-template/examples/hello-go/greet"
+)
+
+func TestTemplateRenderer(t *testing.T) {
+	tmpl, err := parseTemplate(`{{upper .Name}}/{{title "mary jane"}}/{{.Locale}}/{{.GoVersion}}/{{.Time.Format "15:04"}}`)
+	if err != nil {
+		t.Fatalf("parseTemplate error: %v", err)
+	}
+	now := func() time.Time { return time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC) }
+	got, err := templateRenderer(tmpl, greet.French, now)(greet.Result{Name: "Sam", GoVersion: "go1.23"})
+	if err != nil {
+		t.Fatalf("render error: %v", err)
+	}
+	if want := "SAM/Mary Jane/fr/go1.23/09:30"; got != want {
+		t.Errorf("rendered %q, want %q", got, want)
+	}
+}
+
+func TestRunTemplate(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	args := []string{"hello-go", "-template", "Hi {{.Name}} ({{.GoVersion}})", "Sam", "Ana"}
+	if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+	v := runtime.Version()
+	want := "Hi Sam (" + v + ")\nHi Ana (" + v + ")\nGo version: " + v + "\n"
+	if got := stdout.String(); got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+}
+
+func TestRunTemplateInvalid(t *testing.T) {
+	tests := []struct {
+		name, template, wantErr string
+	}{
+		{"unknown field", "Hi {{.Nickname}}", "can't evaluate field Nickname"},
+		{"malformed", "Hi {{.Name", "unclosed action"},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		args := []string{"hello-go", "-template", tt.template, "Sam"}
+		if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, nil, nil); code != exitUsage {
+			t.Errorf("%s: exit code = %d, want %d", tt.name, code, exitUsage)
+		}
+		if stdout.Len() != 0 {
+			t.Errorf("%s: stdout = %q, want empty", tt.name, stdout.String())
+		}
+		if !strings.Contains(stderr.String(), tt.wantErr) {
+			t.Errorf("%s: stderr = %q, want %q", tt.name, stderr.String(), tt.wantErr)
+		}
+	}
+}
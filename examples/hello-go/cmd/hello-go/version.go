@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"runtime/debug"
+)
+
+// Build metadata reported by the version subcommand. They can be set at link
+// time, e.g.
+//
+//	go build -ldflags "-X main.Version=1.1.0 -X main.Commit=$(git rev-parse HEAD)"
+//
+// and otherwise fall back to the module version and VCS stamps recorded in
+// the binary's build info.
+var (
+	Version   string
+	Commit    string
+	BuildDate string
+)
+
+// unknown is reported for any build field that cannot be determined.
+const unknown = "unknown"
+
+// buildInfo is the resolved build metadata of the running binary.
+type buildInfo struct {
+	Version   string
+	Commit    string
+	BuildDate string
+	GoVersion string
+}
+
+// readBuildInfo resolves the build metadata, preferring the link-time
+// variables over the values embedded by the Go toolchain. The Go version is
+// the one reported by goVersion.
+func readBuildInfo(goVersion func() string) buildInfo {
+	bi := buildInfo{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: goVersion(),
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if bi.Version == "" && info.Main.Version != "(devel)" {
+			bi.Version = info.Main.Version
+		}
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if bi.Commit == "" {
+					bi.Commit = s.Value
+				}
+			case "vcs.time":
+				if bi.BuildDate == "" {
+					bi.BuildDate = s.Value
+				}
+			}
+		}
+	}
+	for _, f := range []*string{&bi.Version, &bi.Commit, &bi.BuildDate} {
+		if *f == "" {
+			*f = unknown
+		}
+	}
+	return bi
+}
+
+// writeVersion writes the output of the version subcommand to w.
+func writeVersion(w io.Writer, goVersion func() string) error {
+	bi := readBuildInfo(goVersion)
+	return writeAll(w, fmt.Sprintf("Version: %s\nCommit: %s\nBuilt: %s\nGo version: %s\n",
+		bi.Version, bi.Commit, bi.BuildDate, bi.GoVersion))
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRunVersion(t *testing.T) {
+	var stdout bytes.Buffer
+	if code := run(context.Background(), []string{"hello-go", "version"}, nil, &stdout, io.Discard, noEnv, nil, nil); code != 0 {
+		t.Fatalf("exit code = %d, want 0", code)
+	}
+	fields := map[string]string{}
+	sc := bufio.NewScanner(&stdout)
+	for sc.Scan() {
+		key, value, ok := strings.Cut(sc.Text(), ": ")
+		if !ok {
+			t.Fatalf("malformed version line %q", sc.Text())
+		}
+		fields[key] = value
+	}
+	for _, key := range []string{"Version", "Commit", "Built"} {
+		if fields[key] == "" {
+			t.Errorf("%s line missing or empty", key)
+		}
+	}
+	if got := fields["Go version"]; got != runtime.Version() {
+		t.Errorf("Go version = %q, want %q", got, runtime.Version())
+	}
+}
+
+func TestReadBuildInfoOverride(t *testing.T) {
+	defer func(v, c, d string) { Version, Commit, BuildDate = v, c, d }(Version, Commit, BuildDate)
+	Version, Commit, BuildDate = "1.2.3", "abc123", "2024-01-02"
+
+	bi := readBuildInfo(runtime.Version)
+	if bi.Version != "1.2.3" || bi.Commit != "abc123" || bi.BuildDate != "2024-01-02" {
+		t.Errorf("readBuildInfo() = %+v, want link-time values", bi)
+	}
+}
+
+func TestRunShowGoVersion(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"Sam"}, "Hello, Sam! 🐹\nGo version: go0.0-test\n"},
+		{[]string{"-show-go-version=false", "Sam"}, "Hello, Sam! 🐹\n"},
+		{[]string{"-format=json", "Sam"}, `{"name":"Sam","greeting":"Hello, Sam! 🐹","goVersion":"go0.0-test"}` + "\n"},
+		{[]string{"-format=json", "-show-go-version=false", "Sam"}, `{"name":"Sam","greeting":"Hello, Sam! 🐹"}` + "\n"},
+		{[]string{"version"}, "Version: unknown\nCommit: unknown\nBuilt: unknown\nGo version: go0.0-test\n"},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		args := append([]string{"hello-go"}, tt.args...)
+		if code := run(context.Background(), args, nil, &stdout, &stderr, noEnv, fixedVersion("go0.0-test"), nil); code != 0 {
+			t.Fatalf("%q: exit code = %d, want 0 (stderr %q)", tt.args, code, stderr.String())
+		}
+		if got := stdout.String(); got != tt.want {
+			t.Errorf("%q: stdout = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
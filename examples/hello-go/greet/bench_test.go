@@ -0,0 +1,93 @@
+package greet
+
+import (
+	"strings"
+	"testing"
+)
+
+// Representative inputs for the benchmarks.
+var (
+	benchShortName   = "Alice"
+	benchUnicodeName = strings.Repeat("Zoë Ångström-Łukasiewicz 山田太郎 🎉 ", 8)
+	benchHostileName = strings.Repeat("\x1b[31mEve\x1b[0m\n", 8)
+)
+
+func BenchmarkGreet(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		Greet(benchShortName)
+	}
+}
+
+func BenchmarkGreetIn(b *testing.B) {
+	for _, locale := range []Locale{English, Japanese} {
+		b.Run(string(locale), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				if _, err := GreetIn(locale, benchUnicodeName); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSanitizeName(b *testing.B) {
+	for _, bm := range []struct{ name, in string }{
+		{"ascii", benchShortName},
+		{"unicode", benchUnicodeName},
+		{"escapes", benchHostileName},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(bm.in)))
+			for range b.N {
+				SanitizeName(bm.in)
+			}
+		})
+	}
+}
+
+func BenchmarkGreeterGreet(b *testing.B) {
+	for _, sanitize := range []bool{true, false} {
+		g, err := New(WithLocale(French), WithSanitize(sanitize))
+		if err != nil {
+			b.Fatal(err)
+		}
+		name := "raw"
+		if sanitize {
+			name = "sanitized"
+		}
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				g.Greet(benchUnicodeName)
+			}
+		})
+	}
+}
+
+// BenchmarkGreeterDuplicates greets a stream in which a few names repeat,
+// like -repeat or a log of visitors, with and without WithCache.
+func BenchmarkGreeterDuplicates(b *testing.B) {
+	names := make([]string, 1000)
+	for i := range names {
+		names[i] = benchUnicodeName + string(rune('A'+i%10))
+	}
+	for _, size := range []int{0, 64} {
+		g, err := New(WithLocale(French), WithNormalize(true), WithCache(size))
+		if err != nil {
+			b.Fatal(err)
+		}
+		name := "nocache"
+		if size > 0 {
+			name = "cache"
+		}
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := range b.N {
+				g.Greet(names[i%len(names)])
+			}
+		})
+	}
+}
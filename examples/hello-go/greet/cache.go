@@ -0,0 +1,73 @@
+package greet
+
+import (
+	"container/list"
+	"sync"
+)
+
+// cacheKey identifies a cached greeting. The other options of a Greeter are
+// fixed for the lifetime of its cache; see Greeter.With.
+type cacheKey struct {
+	name   string // the name as given, before it is resolved
+	locale Locale
+	word   string
+	emoji  string
+}
+
+// cacheEntry is a cached greeting with the resolved name it greets.
+type cacheEntry struct {
+	key      cacheKey
+	name     string
+	greeting string
+}
+
+// greetingCache is a least recently used cache of greetings, safe for
+// concurrent use.
+type greetingCache struct {
+	size int
+
+	mu      sync.Mutex
+	lru     *list.List // of *cacheEntry, most recent first
+	entries map[cacheKey]*list.Element
+}
+
+// newGreetingCache returns an empty cache holding up to size greetings.
+func newGreetingCache(size int) *greetingCache {
+	return &greetingCache{size: size, lru: list.New(), entries: map[cacheKey]*list.Element{}}
+}
+
+// get returns the entry for k and whether there is one.
+func (c *greetingCache) get(k cacheKey) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[k]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	c.lru.MoveToFront(e)
+	return *e.Value.(*cacheEntry), true
+}
+
+// add stores entry, evicting the least recently used one if the cache is
+// full.
+func (c *greetingCache) add(entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[entry.key]; ok {
+		c.lru.MoveToFront(e)
+		return
+	}
+	if c.lru.Len() >= c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+	c.entries[entry.key] = c.lru.PushFront(&entry)
+}
+
+// len returns the number of cached greetings.
+func (c *greetingCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
@@ -0,0 +1,97 @@
+package greet
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestGreeterCache(t *testing.T) {
+	plain, err := New(WithLocale(French))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached, err := New(WithLocale(French), WithCache(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"Ana", " Ana ", "Bob", "Ana", "", "\x1b[31mEve", "Bob"} {
+		if got, want := cached.Result(name), plain.Result(name); got != want {
+			t.Errorf("cached Result(%q) = %+v, want %+v", name, got, want)
+		}
+	}
+	if n := cached.cache.len(); n != 2 {
+		t.Errorf("cache holds %d greetings, want 2", n)
+	}
+	if plain.cache != nil {
+		t.Error("Greeter without WithCache has a cache")
+	}
+}
+
+func TestGreeterCacheWith(t *testing.T) {
+	g, err := New(WithCache(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Greet("ana")
+
+	// The locale, word and emoji are part of the key, so the cache is shared.
+	for _, opt := range []Option{WithLocale(German), WithWord("Hi"), WithEmoji("🎉")} {
+		c, err := g.With(opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.cache != g.cache {
+			t.Error("With changing a cache key field did not share the cache")
+		}
+	}
+	de, _ := g.With(WithLocale(German))
+	if got, want := de.Greet("ana"), "Hallo, ana! 👋"; got != want {
+		t.Errorf("shared cache: Greet = %q, want %q", got, want)
+	}
+
+	// Other options get a cache of their own.
+	titled, err := g.With(WithTitleCase(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if titled.cache == g.cache {
+		t.Error("With changing the name pipeline shared the cache")
+	}
+	if got, want := titled.Greet("ana"), "Hello, Ana! 🐹"; got != want {
+		t.Errorf("Greet = %q, want %q", got, want)
+	}
+
+	off, err := g.With(WithCache(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if off.cache != nil {
+		t.Error("WithCache(0) did not disable the cache")
+	}
+}
+
+func TestGreeterCacheConcurrent(t *testing.T) {
+	g, err := New(WithCache(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{"Ana", "Bob", "Eva", "Ken", "Yui", "Luis"}
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 200 {
+				name := names[(i+j)%len(names)]
+				if got, want := g.Greet(name), "Hello, "+name+"! 🐹"; got != want {
+					t.Errorf("Greet = %q, want %q", got, want)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if n := g.cache.len(); n > 4 {
+		t.Errorf("cache holds %d greetings, want at most 4", n)
+	}
+}
@@ -0,0 +1,28 @@
+package greet
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupportedLocale is reported, wrapped in a LocaleError, when a greeting
+// is requested in a locale without a template.
+var ErrUnsupportedLocale = errors.New("unsupported locale")
+
+// A LocaleError records the unsupported locale of a failed request. It wraps
+// ErrUnsupportedLocale.
+type LocaleError struct {
+	Locale Locale
+}
+
+func (e LocaleError) Error() string {
+	return fmt.Sprintf("unsupported locale %q", e.Locale)
+}
+
+// Unwrap returns ErrUnsupportedLocale.
+func (e LocaleError) Unwrap() error {
+	return ErrUnsupportedLocale
+}
+
+// ErrNameTooLong is reported by ApplyLengthPolicy when it rejects a name.
+var ErrNameTooLong = errors.New("name too long")
@@ -0,0 +1,35 @@
+package greet
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLocaleError(t *testing.T) {
+	_, greetErr := GreetIn("xx", "Sam")
+	_, newErr := New(WithLocale("xx"))
+	g, _ := New()
+	_, withErr := g.With(WithLocale("xx"))
+	_, groupErr := GreetGroup("xx", nil)
+	for _, err := range []error{greetErr, newErr, withErr, groupErr} {
+		if !errors.Is(err, ErrUnsupportedLocale) {
+			t.Errorf("errors.Is(%v, ErrUnsupportedLocale) = false", err)
+		}
+		var le LocaleError
+		if !errors.As(err, &le) {
+			t.Errorf("errors.As(%v, &LocaleError{}) = false", err)
+		} else if le.Locale != "xx" {
+			t.Errorf("LocaleError.Locale = %q, want %q", le.Locale, "xx")
+		}
+		if want := `unsupported locale "xx"`; err.Error() != want {
+			t.Errorf("Error() = %q, want %q", err.Error(), want)
+		}
+	}
+}
+
+func TestOtherErrorsAreNotLocaleErrors(t *testing.T) {
+	_, err := New(WithEmoji("🐹🐹"))
+	if err == nil || errors.Is(err, ErrUnsupportedLocale) {
+		t.Errorf("New with invalid emoji error = %v, want a non-locale error", err)
+	}
+}
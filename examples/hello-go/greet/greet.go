@@ -0,0 +1,133 @@
+// Package greet builds localized greetings. It is the library behind the
+// hello-go command and does not depend on the command line or the process
+// environment.
+package greet
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// DefaultName is greeted when no name is given.
+const DefaultName = "World"
+
+// DefaultEmoji ends the greetings of locales without an emoji of their own,
+// such as those added with WithTemplates. See Locale.DefaultEmoji.
+const DefaultEmoji = "🐹"
+
+// Locale identifies the language of a greeting, e.g. "en" or "fr".
+type Locale string
+
+// Supported locales.
+const (
+	English  Locale = "en"
+	French   Locale = "fr"
+	Spanish  Locale = "es"
+	German   Locale = "de"
+	Japanese Locale = "ja"
+	Arabic   Locale = "ar"
+	Hebrew   Locale = "he"
+)
+
+// DefaultLocale is used when no locale is requested.
+const DefaultLocale = English
+
+// A localeEntry describes how a built-in locale greets.
+type localeEntry struct {
+	// Word is the greeting word, which WithWord replaces.
+	Word string
+	// Punct places the word, the name and the emoji, in that order, at its
+	// three %s; punctuation and emoji placement differ per language.
+	Punct string
+	// Emoji is the default emoji of the locale.
+	Emoji string
+}
+
+// template returns the greeting template of e, with its word filled in and
+// placeholders left for the name and the emoji.
+func (e localeEntry) template() string {
+	return strings.Replace(e.Punct, "%s", e.Word, 1)
+}
+
+// builtinLocales holds the supported locales. Lookups go through the
+// compiled table in locales.go.
+var builtinLocales = map[Locale]localeEntry{
+	English:  {"Hello", "%s, %s! %s", DefaultEmoji},
+	French:   {"Bonjour", "%s, %s ! %s", "👋"},
+	Spanish:  {"Hola", "¡%s, %s! %s", "👋"},
+	German:   {"Hallo", "%s, %s! %s", "👋"},
+	Japanese: {"こんにちは", "%s、%sさん！%s", "🙇"},
+	Arabic:   {"مرحبا", "%s، %s! %s", "👋"},
+	Hebrew:   {"שלום", "%s, %s! %s", "👋"},
+}
+
+// Greet returns the English greeting for name. The name is sanitized with
+// SanitizeName, surrounding whitespace is trimmed and an empty name falls
+// back to DefaultName.
+func Greet(name string) string {
+	s, _ := GreetIn(DefaultLocale, name)
+	return s
+}
+
+// GreetDefault returns the greeting for DefaultName.
+func GreetDefault() string {
+	return Greet(DefaultName)
+}
+
+// GreetIn returns the greeting for name in the given locale. It returns a
+// LocaleError if the locale is not supported.
+func GreetIn(locale Locale, name string) (string, error) {
+	g, err := New(WithLocale(locale))
+	if err != nil {
+		return "", err
+	}
+	return g.Greet(name), nil
+}
+
+// TimeOfDayGreeting returns an English greeting for name that depends on the
+// hour of now: morning from 05:00 to 11:59, afternoon from 12:00 to 17:59 and
+// evening otherwise.
+func TimeOfDayGreeting(now time.Time, name string) string {
+	return fmt.Sprintf("Good %s, %s!", partOfDay(now), resolveName(name))
+}
+
+// partOfDay returns the part of the day TimeOfDayGreeting names for now.
+func partOfDay(now time.Time) string {
+	switch h := now.Hour(); {
+	case h >= 5 && h < 12:
+		return "morning"
+	case h >= 12 && h < 18:
+		return "afternoon"
+	default:
+		return "evening"
+	}
+}
+
+// Result is a single greeting together with the name it was produced for.
+// GoVersion is empty when the Greeter does not report it; see WithGoVersion.
+type Result struct {
+	Name      string `json:"name"`
+	Greeting  string `json:"greeting"`
+	GoVersion string `json:"goVersion,omitempty"`
+}
+
+// Text returns the plain-text rendering of r: the greeting line itself.
+func (r Result) Text() string {
+	return r.Greeting
+}
+
+// goVersion reports the Go version recorded in results by default.
+func goVersion() string {
+	return runtime.Version()
+}
+
+// resolveName trims name and substitutes DefaultName for an empty one.
+func resolveName(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return DefaultName
+	}
+	return name
+}
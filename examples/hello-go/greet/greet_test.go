@@ -0,0 +1,84 @@
+package greet
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGreet(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"empty", "", "Hello, World! 🐹"},
+		{"normal", "Alice", "Hello, Alice! 🐹"},
+		{"trailing whitespace", "Alice  \n", "Hello, Alice! 🐹"},
+		{"only whitespace", "   ", "Hello, World! 🐹"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Greet(tt.in); got != tt.want {
+				t.Errorf("Greet(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGreetDefault(t *testing.T) {
+	if got, want := GreetDefault(), "Hello, World! 🐹"; got != want {
+		t.Errorf("GreetDefault() = %q, want %q", got, want)
+	}
+}
+
+func TestGreetIn(t *testing.T) {
+	tests := []struct {
+		locale Locale
+		want   string
+	}{
+		{English, "Hello, Marie! 🐹"},
+		{French, "Bonjour, Marie ! 👋"},
+		{Spanish, "¡Hola, Marie! 👋"},
+		{German, "Hallo, Marie! 👋"},
+		{Japanese, "こんにちは、Marieさん！🙇"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.locale), func(t *testing.T) {
+			got, err := GreetIn(tt.locale, "Marie")
+			if err != nil {
+				t.Fatalf("GreetIn(%q) error: %v", tt.locale, err)
+			}
+			if got != tt.want {
+				t.Errorf("GreetIn(%q) = %q, want %q", tt.locale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGreetInUnsupported(t *testing.T) {
+	if _, err := GreetIn("xx", "Marie"); err == nil {
+		t.Error("GreetIn(\"xx\") returned nil error")
+	}
+}
+
+func TestTimeOfDayGreeting(t *testing.T) {
+	tests := []struct {
+		hour int
+		want string
+	}{
+		{0, "Good evening, Sam!"},
+		{4, "Good evening, Sam!"},
+		{5, "Good morning, Sam!"},
+		{11, "Good morning, Sam!"},
+		{12, "Good afternoon, Sam!"},
+		{17, "Good afternoon, Sam!"},
+		{18, "Good evening, Sam!"},
+		{23, "Good evening, Sam!"},
+	}
+	for _, tt := range tests {
+		now := time.Date(2024, time.March, 1, tt.hour, 30, 0, 0, time.UTC)
+		if got := TimeOfDayGreeting(now, "Sam"); got != tt.want {
+			t.Errorf("TimeOfDayGreeting(%02d:30) = %q, want %q", tt.hour, got, tt.want)
+		}
+	}
+}
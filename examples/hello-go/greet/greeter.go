@@ -0,0 +1,307 @@
+package greet
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/rivo/uniseg"
+)
+
+// A Greeter builds greetings with a fixed set of options. Create one with
+// New; the zero value is not usable. A Greeter is immutable and safe for
+// concurrent use.
+type Greeter struct {
+	locale   Locale
+	emoji    string
+	emojiSet bool // emoji overrides the locale's default emoji
+	word     string
+	now      func() time.Time
+	decorate func(string) string
+	raw      bool
+	nfc      bool
+	title    bool
+	noBidi   bool
+	custom   map[Locale]compiledTemplate
+	version  func() string
+	wrap     int
+	cache    *greetingCache
+	restyled bool  // an option other than the cache key changed; see With
+	err      error // an invalid option value, reported by With
+}
+
+// An Option configures a Greeter.
+type Option func(*Greeter)
+
+// WithLocale selects the greeting language. It defaults to DefaultLocale.
+func WithLocale(l Locale) Option {
+	return func(g *Greeter) { g.locale = l }
+}
+
+// WithEmoji replaces the locale's default emoji at the end of the greeting,
+// in every locale. The emoji must be a single grapheme cluster; an empty
+// emoji drops it from the greeting.
+func WithEmoji(emoji string) Option {
+	return func(g *Greeter) { g.emoji, g.emojiSet = emoji, true }
+}
+
+// WithWord replaces the locale's greeting word, such as "Hello" or "Bonjour",
+// while keeping the locale's punctuation and emoji placement. An empty word
+// restores the default. It cannot be combined with WithTimeOfDay.
+func WithWord(word string) Option {
+	return func(g *Greeter) { g.word = strings.TrimSpace(word) }
+}
+
+// WithTemplates adds greeting templates for the given locales, overriding
+// the built-in template of a locale that already exists. Each template must
+// pass ValidateTemplate. Custom templates are not affected by WithWord, and
+// group greetings in new locales use English list formatting.
+func WithTemplates(templates map[Locale]string) Option {
+	return func(g *Greeter) {
+		if len(templates) == 0 {
+			return
+		}
+		g.restyled = true
+		custom := maps.Clone(g.custom)
+		if custom == nil {
+			custom = make(map[Locale]compiledTemplate, len(templates))
+		}
+		for l, tmpl := range templates {
+			custom[l] = compileTemplate(tmpl, "")
+			if g.err == nil {
+				if err := ValidateTemplate(tmpl); err != nil {
+					g.err = fmt.Errorf("locale %q: %w", l, err)
+				}
+			}
+		}
+		g.custom = custom
+	}
+}
+
+// WithWrap wraps greetings onto lines of at most width terminal columns as
+// WrapGreeting does, except that a name is never split: the lines of a group
+// greeting break only between the names. A width of zero or less, the
+// default, leaves greetings on one line.
+func WithWrap(width int) Option {
+	return func(g *Greeter) { g.wrap, g.restyled = width, true }
+}
+
+// WithGoVersion sets the function reporting the Go version recorded in
+// results, which defaults to runtime.Version. A nil version leaves
+// Result.GoVersion empty.
+func WithGoVersion(version func() string) Option {
+	return func(g *Greeter) { g.version = version }
+}
+
+// WithTimeOfDay switches to the English time-of-day greeting, reading the
+// current time from now. See TimeOfDayGreeting.
+func WithTimeOfDay(now func() time.Time) Option {
+	return func(g *Greeter) { g.now, g.restyled = now, true }
+}
+
+// WithNameDecorator passes the resolved name through decorate before it is
+// inserted into the greeting, e.g. to wrap it in terminal color escapes.
+// Result.Name is left undecorated.
+func WithNameDecorator(decorate func(string) string) Option {
+	return func(g *Greeter) { g.decorate, g.restyled = decorate, true }
+}
+
+// WithSanitize controls whether names are passed through SanitizeName before
+// they are greeted. Sanitizing is enabled by default; disable it only for
+// trusted input.
+func WithSanitize(enabled bool) Option {
+	return func(g *Greeter) { g.raw, g.restyled = !enabled, true }
+}
+
+// WithNormalize controls whether names are converted to Unicode
+// Normalization Form C with NormalizeName. It is disabled by default.
+func WithNormalize(enabled bool) Option {
+	return func(g *Greeter) { g.nfc, g.restyled = enabled, true }
+}
+
+// WithTitleCase controls whether the first grapheme cluster of each name is
+// capitalized with TitleCaseName. It is disabled by default.
+func WithTitleCase(enabled bool) Option {
+	return func(g *Greeter) { g.title, g.restyled = enabled, true }
+}
+
+// WithBidi controls whether names in right-to-left locales are wrapped in
+// Unicode bidi isolates (U+2068 and U+2069) so that they display correctly.
+// It is enabled by default and has no effect on left-to-right locales.
+func WithBidi(enabled bool) Option {
+	return func(g *Greeter) { g.noBidi, g.restyled = !enabled, true }
+}
+
+// WithCache memoizes up to size greetings in a least recently used cache,
+// which pays off for input with many repeated names. The cache is safe for
+// concurrent use and is shared by the Greeters derived with With that only
+// change the locale, word or emoji. A size of 0, the default, disables it.
+// Time-of-day greetings are never cached.
+func WithCache(size int) Option {
+	return func(g *Greeter) {
+		g.cache = nil
+		if size > 0 {
+			g.cache = newGreetingCache(size)
+		}
+	}
+}
+
+// New returns a Greeter configured by opts. It returns an error if the
+// resulting configuration is invalid, such as a LocaleError for an
+// unsupported locale.
+func New(opts ...Option) (*Greeter, error) {
+	g := &Greeter{locale: DefaultLocale, version: goVersion}
+	return g.With(opts...)
+}
+
+// With returns a copy of g with opts applied on top of its configuration.
+// The copy shares g's cache if opts change at most the locale, word and
+// emoji, which are part of the cache key, and gets a new one otherwise.
+func (g *Greeter) With(opts ...Option) (*Greeter, error) {
+	c := *g
+	c.restyled = false
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.restyled && c.cache != nil && c.cache == g.cache {
+		c.cache = newGreetingCache(c.cache.size)
+	}
+	if _, ok := c.template(); !ok {
+		return nil, LocaleError{c.locale}
+	}
+	if c.emojiSet && c.emoji != "" && uniseg.GraphemeClusterCount(c.emoji) != 1 {
+		return nil, fmt.Errorf("emoji %q must be a single character", c.emoji)
+	}
+	if c.now != nil && c.locale != English {
+		return nil, fmt.Errorf("the time-of-day greeting is only available in English, not %q", c.locale)
+	}
+	if c.now != nil && c.word != "" {
+		return nil, fmt.Errorf("the greeting word cannot be changed for the time-of-day greeting")
+	}
+	return &c, nil
+}
+
+// Supports reports whether g can greet in l, either with a built-in or a
+// custom template.
+func (g *Greeter) Supports(l Locale) bool {
+	_, ok := g.custom[l]
+	return ok || l.Supported()
+}
+
+// Locale returns the locale g greets in.
+func (g *Greeter) Locale() Locale {
+	return g.locale
+}
+
+// Greet returns the greeting for name. The name is sanitized unless disabled
+// with WithSanitize, surrounding whitespace is trimmed and an empty name
+// falls back to DefaultName.
+func (g *Greeter) Greet(name string) string {
+	return g.Result(name).Greeting
+}
+
+// Result returns the greeting for name together with the resolved name.
+func (g *Greeter) Result(name string) Result {
+	if g.cache == nil || g.now != nil {
+		resolved, shown := g.resolve(name)
+		return Result{Name: resolved, Greeting: g.greeting(shown), GoVersion: g.GoVersion()}
+	}
+	k := cacheKey{name, g.locale, g.word, g.currentEmoji()}
+	e, ok := g.cache.get(k)
+	if !ok {
+		resolved, shown := g.resolve(name)
+		e = cacheEntry{k, resolved, g.greeting(shown)}
+		g.cache.add(e)
+	}
+	return Result{Name: e.name, Greeting: e.greeting, GoVersion: g.GoVersion()}
+}
+
+// GreetAll returns the Result for each of names, in order. It is Result
+// applied to every name: there are no per-name errors, since the name
+// pipeline accepts any input. Empty input yields an empty, non-nil slice.
+func (g *Greeter) GreetAll(names []string) []Result {
+	results := make([]Result, 0, len(names))
+	for _, name := range names {
+		results = append(results, g.Result(name))
+	}
+	return results
+}
+
+// GoVersion returns the Go version g records in results, or "" if it does
+// not report one.
+func (g *Greeter) GoVersion() string {
+	if g.version == nil {
+		return ""
+	}
+	return g.version()
+}
+
+// resolve passes name through the configured name pipeline. It returns the
+// resolved name and the decorated form that is shown in the greeting.
+func (g *Greeter) resolve(name string) (resolved, shown string) {
+	if !g.raw {
+		name = SanitizeName(name)
+	}
+	if g.nfc {
+		name = NormalizeName(name)
+	}
+	name = resolveName(name)
+	if g.title {
+		name = TitleCaseName(name)
+	}
+	shown = name
+	if g.decorate != nil {
+		shown = g.decorate(name)
+	}
+	if !g.noBidi {
+		shown = wrapBidi(g.locale, shown)
+	}
+	return name, shown
+}
+
+// greeting returns the greeting for already resolved and decorated names,
+// joined in the list style of g's locale and wrapped as set by WithWrap.
+func (g *Greeter) greeting(shown ...string) string {
+	if g.wrap <= 0 {
+		joined := joinList(g.locale, shown)
+		if g.now != nil {
+			return TimeOfDayGreeting(g.now(), joined)
+		}
+		t, _ := g.template()
+		return format(t, g.word, joined, g.currentEmoji())
+	}
+	before, after := g.frame()
+	return wrapWords(greetingWords(before, shown, listStyleOf(g.locale), after), g.wrap)
+}
+
+// frame returns the text of g's greetings before and after the names.
+func (g *Greeter) frame() (before, after string) {
+	if g.now != nil {
+		return "Good " + partOfDay(g.now()) + ", ", "!"
+	}
+	t, _ := g.template()
+	return t.frame(g.word, g.currentEmoji())
+}
+
+// currentEmoji returns the emoji ending g's greetings: the one set with
+// WithEmoji, or else the default of g's locale.
+func (g *Greeter) currentEmoji() string {
+	if g.emojiSet {
+		return g.emoji
+	}
+	return g.locale.DefaultEmoji()
+}
+
+// template returns the compiled template of g's locale, preferring a custom
+// one, and whether there is any.
+func (g *Greeter) template() (compiledTemplate, bool) {
+	if t, ok := g.custom[g.locale]; ok {
+		return t, true
+	}
+	t, ok := locales().byLocale[g.locale]
+	return t, ok
+}
@@ -0,0 +1,263 @@
+package greet
+
+import (
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGreeterOptions(t *testing.T) {
+	evening := func() time.Time { return time.Date(2024, time.March, 1, 20, 0, 0, 0, time.UTC) }
+	brackets := func(name string) string { return "[" + name + "]" }
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"defaults", nil, "Hello, Sam! 🐹"},
+		{"locale", []Option{WithLocale("fr")}, "Bonjour, Sam ! 👋"},
+		{"emoji", []Option{WithEmoji("🎉")}, "Hello, Sam! 🎉"},
+		{"locale and emoji", []Option{WithLocale("fr"), WithEmoji("🎉")}, "Bonjour, Sam ! 🎉"},
+		{"japanese emoji placement", []Option{WithLocale(Japanese), WithEmoji("🎉")}, "こんにちは、Samさん！🎉"},
+		{"no emoji", []Option{WithEmoji("")}, "Hello, Sam!"},
+		{"no emoji french", []Option{WithLocale(French), WithEmoji("")}, "Bonjour, Sam !"},
+		{"multi-rune emoji", []Option{WithEmoji("👩‍👩‍👧")}, "Hello, Sam! 👩‍👩‍👧"},
+		{"flag emoji", []Option{WithEmoji("🇫🇷")}, "Hello, Sam! 🇫🇷"},
+		{"decorator", []Option{WithLocale(German), WithNameDecorator(brackets)}, "Hallo, [Sam]! 👋"},
+		{"time of day", []Option{WithTimeOfDay(evening)}, "Good evening, Sam!"},
+		{"time of day decorated", []Option{WithTimeOfDay(evening), WithNameDecorator(brackets)}, "Good evening, [Sam]!"},
+		{"rtl isolates name", []Option{WithLocale(Hebrew)}, "שלום, \u2068Sam\u2069! 👋"},
+		{"rtl isolates decorated name", []Option{WithLocale(Arabic), WithNameDecorator(brackets)}, "مرحبا، \u2068[Sam]\u2069! 👋"},
+		{"rtl without bidi", []Option{WithLocale(Arabic), WithBidi(false)}, "مرحبا، Sam! 👋"},
+		{"ltr ignores bidi", []Option{WithBidi(true)}, "Hello, Sam! 🐹"},
+		{"word", []Option{WithWord("Welcome")}, "Welcome, Sam! 🐹"},
+		{"word french", []Option{WithWord("Salut"), WithLocale(French)}, "Salut, Sam ! 👋"},
+		{"word spanish keeps punctuation", []Option{WithLocale(Spanish), WithWord("Buenas")}, "¡Buenas, Sam! 👋"},
+		{"word japanese", []Option{WithLocale(Japanese), WithWord("やあ")}, "やあ、Samさん！🙇"},
+		{"empty word restores default", []Option{WithWord("Hey"), WithWord("")}, "Hello, Sam! 🐹"},
+		{"last option wins", []Option{WithLocale(Spanish), WithLocale(German)}, "Hallo, Sam! 👋"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := New(tt.opts...)
+			if err != nil {
+				t.Fatalf("New error: %v", err)
+			}
+			if got := g.Greet("Sam"); got != tt.want {
+				t.Errorf("Greet = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGreeterInvalid(t *testing.T) {
+	if _, err := New(WithLocale("xx")); err == nil {
+		t.Error("New with unsupported locale returned nil error")
+	}
+	for _, emoji := range []string{"🎉🎉", "🇫🇷🇩🇪", "ab"} {
+		if _, err := New(WithEmoji(emoji)); err == nil {
+			t.Errorf("New with emoji %q returned nil error", emoji)
+		}
+	}
+	if _, err := New(WithLocale(French), WithTimeOfDay(time.Now)); err == nil {
+		t.Error("New with non-English time-of-day greeting returned nil error")
+	}
+	if _, err := New(WithWord("Hey"), WithTimeOfDay(time.Now)); err == nil {
+		t.Error("New with word and time-of-day greeting returned nil error")
+	}
+}
+
+func TestGreeterWith(t *testing.T) {
+	g, err := New(WithLocale(French))
+	if err != nil {
+		t.Fatal(err)
+	}
+	es, err := g.With(WithLocale(Spanish))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Locale() != French || es.Locale() != Spanish {
+		t.Errorf("locales = %q, %q; want fr, es", g.Locale(), es.Locale())
+	}
+}
+
+func TestGreeterResult(t *testing.T) {
+	g, err := New(WithLocale(Spanish), WithNameDecorator(strings.ToUpper))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := g.Result(" Luis ")
+	if r.Name != "Luis" || r.Greeting != "¡Hola, LUIS! 👋" || r.GoVersion == "" {
+		t.Errorf("Result = %+v", r)
+	}
+	if r.Text() != r.Greeting {
+		t.Errorf("Text() = %q, want %q", r.Text(), r.Greeting)
+	}
+	if got := g.Greet(""); got != "¡Hola, WORLD! 👋" {
+		t.Errorf("Greet(\"\") = %q, want default name", got)
+	}
+}
+
+// TestNoProcessDependencies keeps the library free of the command line and
+// process environment so that it can be embedded anywhere.
+func TestNoProcessDependencies(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, parser.ImportsOnly)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, imp := range f.Imports {
+			path, _ := strconv.Unquote(imp.Path.Value)
+			if path == "os" || path == "flag" {
+				t.Errorf("%s imports %q", file, path)
+			}
+		}
+	}
+}
+
+func TestGreeterTemplates(t *testing.T) {
+	custom := WithTemplates(map[Locale]string{
+		"pt":    "Olá, %s! %s",
+		English: "Howdy, %s! %s",
+		"x-bow": "%s, bow",
+	})
+	tests := []struct {
+		opts []Option
+		want string
+	}{
+		{[]Option{custom, WithLocale("pt")}, "Olá, Sam! 🐹"},
+		{[]Option{custom}, "Howdy, Sam! 🐹"},
+		{[]Option{custom, WithLocale(French)}, "Bonjour, Sam ! 👋"},
+		{[]Option{custom, WithLocale("x-bow")}, "Sam, bow"},
+		{[]Option{custom, WithWord("Hey")}, "Howdy, Sam! 🐹"},
+	}
+	for _, tt := range tests {
+		g, err := New(tt.opts...)
+		if err != nil {
+			t.Fatalf("New error: %v", err)
+		}
+		if got := g.Greet("Sam"); got != tt.want {
+			t.Errorf("Greet = %q, want %q", got, tt.want)
+		}
+	}
+
+	g, err := New(custom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.Supports("pt") || !g.Supports(German) || g.Supports("xx") {
+		t.Error("Supports does not cover both built-in and custom locales")
+	}
+	pt, err := g.With(WithLocale("pt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := pt.Group([]string{"Ana", "Rui", "Eva"}).Greeting, "Olá, Ana, Rui, and Eva! 🐹"; got != want {
+		t.Errorf("Group = %q, want %q", got, want)
+	}
+	if Locale("pt").Supported() {
+		t.Error("WithTemplates changed the built-in locale table")
+	}
+}
+
+func TestGreeterTemplatesInvalid(t *testing.T) {
+	for _, tmpl := range []string{"Hello!", "%s %s %s", "Hi \xff %s"} {
+		if _, err := New(WithTemplates(map[Locale]string{"pt": tmpl})); err == nil {
+			t.Errorf("New with template %q returned nil error", tmpl)
+		}
+	}
+}
+
+func TestGreeterGoVersion(t *testing.T) {
+	tests := []struct {
+		opts []Option
+		want string
+	}{
+		{nil, runtime.Version()},
+		{[]Option{WithGoVersion(func() string { return "go0.0-test" })}, "go0.0-test"},
+		{[]Option{WithGoVersion(nil)}, ""},
+	}
+	for _, tt := range tests {
+		g, err := New(tt.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := g.Result("Sam").GoVersion; got != tt.want {
+			t.Errorf("Result.GoVersion = %q, want %q", got, tt.want)
+		}
+		if got := g.Group([]string{"Sam"}).GoVersion; got != tt.want {
+			t.Errorf("Group.GoVersion = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestGreeterLocaleEmoji(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ja, err := g.With(WithLocale(Japanese))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := ja.Greet("Ken"), "こんにちは、Kenさん！"+Japanese.DefaultEmoji(); got != want {
+		t.Errorf("Greet = %q, want the Japanese default emoji in %q", got, want)
+	}
+
+	// An explicit emoji stays when the locale changes.
+	g, err = New(WithEmoji("🎉"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ja, err = g.With(WithLocale(Japanese)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := ja.Greet("Ken"), "こんにちは、Kenさん！🎉"; got != want {
+		t.Errorf("Greet = %q, want %q", got, want)
+	}
+}
+
+func TestGreeterGreetAll(t *testing.T) {
+	g, err := New(WithLocale(French), WithWord("Salut"), WithEmoji("🎉"), WithNormalize(true), WithGoVersion(func() string { return "go0.0-test" }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.GreetAll(nil); got == nil || len(got) != 0 {
+		t.Errorf("GreetAll(nil) = %#v, want an empty, non-nil slice", got)
+	}
+	if got := g.GreetAll([]string{}); got == nil || len(got) != 0 {
+		t.Errorf("GreetAll([]) = %#v, want an empty, non-nil slice", got)
+	}
+
+	got := g.GreetAll([]string{"Ana"})
+	want := []Result{{Name: "Ana", Greeting: "Salut, Ana ! 🎉", GoVersion: "go0.0-test"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("GreetAll(Ana) = %+v, want %+v", got, want)
+	}
+
+	// Escape sequences and control characters are stripped, decomposed
+	// names are normalized and blank names fall back to DefaultName.
+	got = g.GreetAll([]string{"Jo\x1b[31me\x00", "Jose\u0301", "  "})
+	want = []Result{
+		{Name: "Joe", Greeting: "Salut, Joe ! 🎉", GoVersion: "go0.0-test"},
+		{Name: "Jos\u00e9", Greeting: "Salut, Jos\u00e9 ! 🎉", GoVersion: "go0.0-test"},
+		{Name: DefaultName, Greeting: "Salut, World ! 🎉", GoVersion: "go0.0-test"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("GreetAll = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,90 @@
+package greet
+
+import "strings"
+
+// listStyle describes how a locale joins a list of names.
+type listStyle struct {
+	pair string // between the names of a two-name list
+	sep  string // between all but the last two names of a longer list
+	last string // between the last two names of a longer list
+}
+
+// listStyles maps each supported locale to its list formatting. English uses
+// the serial (Oxford) comma.
+var listStyles = map[Locale]listStyle{
+	English:  {pair: " and ", sep: ", ", last: ", and "},
+	French:   {pair: " et ", sep: ", ", last: " et "},
+	Spanish:  {pair: " y ", sep: ", ", last: " y "},
+	German:   {pair: " und ", sep: ", ", last: " und "},
+	Japanese: {pair: "と", sep: "、", last: "、"},
+	Arabic:   {pair: " و", sep: "، ", last: " و"},
+	Hebrew:   {pair: " ו", sep: ", ", last: " ו"},
+}
+
+// listStyleOf returns the list style of locale, falling back to English for
+// locales without one.
+func listStyleOf(locale Locale) listStyle {
+	if style, ok := listStyles[locale]; ok {
+		return style
+	}
+	return listStyles[English]
+}
+
+// separator returns the text between name i-1 and name i of a list of n
+// names, for 0 < i < n.
+func (s listStyle) separator(i, n int) string {
+	switch {
+	case n == 2:
+		return s.pair
+	case i == n-1:
+		return s.last
+	}
+	return s.sep
+}
+
+// joinList joins names in the list style of locale.
+func joinList(locale Locale, names []string) string {
+	if len(names) == 1 {
+		return names[0]
+	}
+	style := listStyleOf(locale)
+	var b strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(style.separator(i, len(names)))
+		}
+		b.WriteString(name)
+	}
+	return b.String()
+}
+
+// GreetGroup returns a single greeting in the given locale addressed to all
+// of names, e.g. "Hello, Alice, Bob, and Carol! 🐹". Each name is resolved
+// as by Greet; without names DefaultName is greeted. It returns a
+// LocaleError if the locale is not supported.
+func GreetGroup(locale Locale, names []string) (string, error) {
+	g, err := New(WithLocale(locale))
+	if err != nil {
+		return "", err
+	}
+	return g.Group(names).Greeting, nil
+}
+
+// Group returns a single greeting addressed to all of names, which are
+// joined in the list style of the Greeter's locale. Result.Name holds the
+// joined, undecorated names. Without names DefaultName is greeted.
+func (g *Greeter) Group(names []string) Result {
+	if len(names) == 0 {
+		names = []string{DefaultName}
+	}
+	resolved := make([]string, len(names))
+	shown := make([]string, len(names))
+	for i, name := range names {
+		resolved[i], shown[i] = g.resolve(name)
+	}
+	return Result{
+		Name:      joinList(g.locale, resolved),
+		Greeting:  g.greeting(shown...),
+		GoVersion: g.GoVersion(),
+	}
+}
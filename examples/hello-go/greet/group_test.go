@@ -0,0 +1,61 @@
+package greet
+
+import "testing"
+
+func TestGreetGroup(t *testing.T) {
+	tests := []struct {
+		locale Locale
+		names  []string
+		want   string
+	}{
+		{English, nil, "Hello, World! 🐹"},
+		{English, []string{"Alice"}, "Hello, Alice! 🐹"},
+		{English, []string{"Alice", "Bob"}, "Hello, Alice and Bob! 🐹"},
+		{English, []string{"Alice", "Bob", "Carol"}, "Hello, Alice, Bob, and Carol! 🐹"},
+		{English, []string{"Alice", "Bob", "Carol", "Dan"}, "Hello, Alice, Bob, Carol, and Dan! 🐹"},
+		{French, nil, "Bonjour, World ! 👋"},
+		{French, []string{"Alice"}, "Bonjour, Alice ! 👋"},
+		{French, []string{"Alice", "Bob"}, "Bonjour, Alice et Bob ! 👋"},
+		{French, []string{"Alice", "Bob", "Carol"}, "Bonjour, Alice, Bob et Carol ! 👋"},
+		{Spanish, []string{"Ana", "Luis", "Eva"}, "¡Hola, Ana, Luis y Eva! 👋"},
+		{Japanese, []string{"Ken", "Yui"}, "こんにちは、KenとYuiさん！🙇"},
+		{English, []string{"  Alice\x1b[31m ", ""}, "Hello, Alice and World! 🐹"},
+	}
+	for _, tt := range tests {
+		got, err := GreetGroup(tt.locale, tt.names)
+		if err != nil {
+			t.Fatalf("GreetGroup(%q, %q) error: %v", tt.locale, tt.names, err)
+		}
+		if got != tt.want {
+			t.Errorf("GreetGroup(%q, %q) = %q, want %q", tt.locale, tt.names, got, tt.want)
+		}
+	}
+}
+
+func TestGreetGroupUnsupported(t *testing.T) {
+	if _, err := GreetGroup("xx", []string{"Alice"}); err == nil {
+		t.Error("GreetGroup with unsupported locale returned nil error")
+	}
+}
+
+func TestGroupResult(t *testing.T) {
+	g, err := New(WithNameDecorator(func(s string) string { return "*" + s + "*" }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := g.Group([]string{"Alice", "Bob"})
+	if want := "Hello, *Alice* and *Bob*! 🐹"; r.Greeting != want {
+		t.Errorf("Greeting = %q, want %q", r.Greeting, want)
+	}
+	if want := "Alice and Bob"; r.Name != want {
+		t.Errorf("Name = %q, want %q", r.Name, want)
+	}
+}
+
+func TestListStylesCoverLocales(t *testing.T) {
+	for _, l := range SupportedLocales() {
+		if _, ok := listStyles[l]; !ok {
+			t.Errorf("locale %q has no list style", l)
+		}
+	}
+}
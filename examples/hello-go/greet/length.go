@@ -0,0 +1,59 @@
+package greet
+
+import (
+	"fmt"
+
+	"github.com/rivo/uniseg"
+)
+
+// A Policy says what ApplyLengthPolicy does with a name that is too long.
+type Policy string
+
+// Policies for names that are too long.
+const (
+	PolicyTruncate Policy = "truncate" // shorten the name and end it with Ellipsis
+	PolicyReject   Policy = "reject"   // fail with ErrNameTooLong
+	PolicySkip     Policy = "skip"     // drop the name
+)
+
+// Ellipsis ends the names shortened by PolicyTruncate.
+const Ellipsis = "…"
+
+// ApplyLengthPolicy limits name to max grapheme clusters, so that a
+// multi-rune emoji or a letter with combining marks counts once. A max of 0
+// or less means no limit. Names within the limit are returned unchanged;
+// longer names are handled according to policy:
+//
+//   - PolicyTruncate keeps the first max-1 grapheme clusters followed by
+//     Ellipsis, never cutting a cluster apart
+//   - PolicyReject returns an error wrapping ErrNameTooLong
+//   - PolicySkip returns false, meaning the name should be dropped
+//
+// The boolean result reports whether the name should be greeted.
+func ApplyLengthPolicy(name string, max int, policy Policy) (string, bool, error) {
+	switch policy {
+	case PolicyTruncate, PolicyReject, PolicySkip:
+	default:
+		return "", false, fmt.Errorf("unknown length policy %q", policy)
+	}
+	if max <= 0 {
+		return name, true, nil
+	}
+	n := uniseg.GraphemeClusterCount(name)
+	if n <= max {
+		return name, true, nil
+	}
+	switch policy {
+	case PolicyReject:
+		return "", false, fmt.Errorf("%w: %d characters, the limit is %d", ErrNameTooLong, n, max)
+	case PolicySkip:
+		return "", false, nil
+	}
+	end, rest, state := 0, name, -1
+	for range max - 1 {
+		var cluster string
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		end += len(cluster)
+	}
+	return name[:end] + Ellipsis, true, nil
+}
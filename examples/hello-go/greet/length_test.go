@@ -0,0 +1,50 @@
+package greet
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestApplyLengthPolicy(t *testing.T) {
+	const family = "\U0001F469\u200d\U0001F469\u200d\U0001F467" // a family emoji of five runes
+	tests := []struct {
+		name   string
+		max    int
+		policy Policy
+		want   string
+		keep   bool
+	}{
+		{"Sam", 0, PolicyReject, "Sam", true},
+		{"Sam", 3, PolicyReject, "Sam", true},
+		{"Samantha", 5, PolicyTruncate, "Sama…", true},
+		{"Samantha", 1, PolicyTruncate, "…", true},
+		{"Ana" + family + "Bo", 5, PolicyTruncate, "Ana" + family + "…", true},
+		{"Ana" + family + "Bo", 4, PolicyTruncate, "Ana…", true},
+		{"Ana" + family, 4, PolicyTruncate, "Ana" + family, true},
+		{"Renée", 5, PolicyTruncate, "Renée", true},
+		{"Renée", 4, PolicyTruncate, "Ren…", true},
+		{"\U0001F1EB\U0001F1F7\U0001F1E9\U0001F1EA!", 2, PolicyTruncate, "\U0001F1EB\U0001F1F7…", true},
+		{"Samantha", 5, PolicySkip, "", false},
+		{"Sam", 5, PolicySkip, "Sam", true},
+	}
+	for _, tt := range tests {
+		got, keep, err := ApplyLengthPolicy(tt.name, tt.max, tt.policy)
+		if err != nil {
+			t.Errorf("ApplyLengthPolicy(%q, %d, %s) error: %v", tt.name, tt.max, tt.policy, err)
+			continue
+		}
+		if got != tt.want || keep != tt.keep {
+			t.Errorf("ApplyLengthPolicy(%q, %d, %s) = %q, %t; want %q, %t", tt.name, tt.max, tt.policy, got, keep, tt.want, tt.keep)
+		}
+	}
+}
+
+func TestApplyLengthPolicyReject(t *testing.T) {
+	_, keep, err := ApplyLengthPolicy("Samantha", 5, PolicyReject)
+	if !errors.Is(err, ErrNameTooLong) || keep {
+		t.Errorf("ApplyLengthPolicy = %t, %v; want false and ErrNameTooLong", keep, err)
+	}
+	if _, _, err := ApplyLengthPolicy("Sam", 5, "shorten"); err == nil {
+		t.Error("ApplyLengthPolicy with unknown policy returned nil error")
+	}
+}
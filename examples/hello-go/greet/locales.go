@@ -0,0 +1,142 @@
+package greet
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// compiledTemplate is a greeting template split around its name and emoji
+// placeholders, so that a greeting is assembled by concatenation.
+type compiledTemplate struct {
+	prefix string // text before the name
+	middle string // text between the name and the emoji
+	suffix string // text after the emoji
+	word   string // the greeting word within prefix, if known
+	emoji  bool   // whether the template has an emoji placeholder
+}
+
+// localeTable is the compiled form of builtinLocales: the single source of
+// truth for which locales exist.
+type localeTable struct {
+	byLocale map[Locale]compiledTemplate
+	sorted   []Locale
+}
+
+var (
+	tableOnce sync.Once
+	table     localeTable
+)
+
+// locales returns the compiled locale table, building it on first use.
+func locales() *localeTable {
+	tableOnce.Do(func() {
+		table.byLocale = make(map[Locale]compiledTemplate, len(builtinLocales))
+		for l, e := range builtinLocales {
+			table.byLocale[l] = compileTemplate(e.template(), e.Word)
+			table.sorted = append(table.sorted, l)
+		}
+		slices.Sort(table.sorted)
+	})
+	return &table
+}
+
+// compileTemplate splits a template at its %s placeholders. word is the
+// greeting word the template starts with, or "" if unknown.
+func compileTemplate(tmpl, word string) compiledTemplate {
+	prefix, rest, _ := strings.Cut(tmpl, "%s")
+	middle, suffix, emoji := strings.Cut(rest, "%s")
+	return compiledTemplate{prefix, middle, suffix, word, emoji}
+}
+
+// ValidateTemplate reports whether tmpl can be used as a greeting template
+// with WithTemplates: it must be valid UTF-8 and contain one %s placeholder
+// for the name, optionally followed by a second one for the emoji.
+func ValidateTemplate(tmpl string) error {
+	if !utf8.ValidString(tmpl) {
+		return fmt.Errorf("template %q is not valid UTF-8", tmpl)
+	}
+	switch strings.Count(tmpl, "%s") {
+	case 0:
+		return fmt.Errorf("template %q has no %%s placeholder for the name", tmpl)
+	case 1, 2:
+		return nil
+	default:
+		return fmt.Errorf("template %q has more than two %%s placeholders (want name and emoji)", tmpl)
+	}
+}
+
+// SupportedLocales returns the supported locales in sorted order. The caller
+// may modify the returned slice.
+func SupportedLocales() []Locale {
+	return slices.Clone(locales().sorted)
+}
+
+// Supported reports whether l has a greeting template.
+func (l Locale) Supported() bool {
+	_, ok := locales().byLocale[l]
+	return ok
+}
+
+// DefaultEmoji returns the emoji that ends greetings in l unless WithEmoji
+// overrides it. Locales that are not built in use the package DefaultEmoji.
+func (l Locale) DefaultEmoji() string {
+	if e, ok := builtinLocales[l]; ok {
+		return e.Emoji
+	}
+	return DefaultEmoji
+}
+
+// rtlLocales are the supported locales written right to left.
+var rtlLocales = map[Locale]bool{
+	Arabic: true,
+	Hebrew: true,
+}
+
+// IsRTL reports whether l is written right to left.
+func (l Locale) IsRTL() bool {
+	return rtlLocales[l]
+}
+
+// Unicode bidi isolate controls (FIRST STRONG ISOLATE and POP DIRECTIONAL
+// ISOLATE).
+const (
+	bidiIsolate = "\u2068"
+	bidiPop     = "\u2069"
+)
+
+// wrapBidi wraps name in bidi isolates when locale is written right to left,
+// so that a left-to-right name cannot reorder the surrounding text.
+func wrapBidi(locale Locale, name string) string {
+	if !locale.IsRTL() {
+		return name
+	}
+	return bidiIsolate + name + bidiPop
+}
+
+// format assembles a greeting from t. A non-empty word replaces the
+// template's greeting word. Without an emoji, or an emoji placeholder, the
+// space that would precede it is dropped too.
+func format(t compiledTemplate, word, name, emoji string) string {
+	if word != "" && t.word != "" {
+		t.prefix = strings.Replace(t.prefix, t.word, word, 1)
+	}
+	if emoji == "" || !t.emoji {
+		return t.prefix + name + strings.TrimSuffix(t.middle+t.suffix, " ")
+	}
+	return t.prefix + name + t.middle + emoji + t.suffix
+}
+
+// frame returns the text that format places before and after the name.
+func (t compiledTemplate) frame(word, emoji string) (before, after string) {
+	before = t.prefix
+	if word != "" && t.word != "" {
+		before = strings.Replace(before, t.word, word, 1)
+	}
+	if emoji == "" || !t.emoji {
+		return before, strings.TrimSuffix(t.middle+t.suffix, " ")
+	}
+	return before, t.middle + emoji + t.suffix
+}
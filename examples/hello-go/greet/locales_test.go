@@ -0,0 +1,118 @@
+package greet
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSupportedLocales(t *testing.T) {
+	locales := SupportedLocales()
+	if len(locales) != len(builtinLocales) {
+		t.Errorf("SupportedLocales() has %d entries, want %d", len(locales), len(builtinLocales))
+	}
+	if !slices.IsSorted(locales) {
+		t.Errorf("SupportedLocales() = %q, not sorted", locales)
+	}
+	for _, l := range locales {
+		if !l.Supported() {
+			t.Errorf("%q listed but not Supported", l)
+		}
+		if s, err := GreetIn(l, "Sam"); err != nil || s == "" {
+			t.Errorf("GreetIn(%q) = %q, %v", l, s, err)
+		}
+	}
+
+	locales[0] = "xx"
+	if SupportedLocales()[0] == "xx" {
+		t.Error("modifying the returned slice changed the table")
+	}
+}
+
+func TestCompileTemplate(t *testing.T) {
+	got := compileTemplate("¡Hola, %s! %s", "Hola")
+	if want := (compiledTemplate{"¡Hola, ", "! ", "", "Hola", true}); got != want {
+		t.Errorf("compileTemplate = %+v, want %+v", got, want)
+	}
+}
+
+func TestIsRTL(t *testing.T) {
+	for _, l := range SupportedLocales() {
+		want := l == Arabic || l == Hebrew
+		if got := l.IsRTL(); got != want {
+			t.Errorf("%q.IsRTL() = %v, want %v", l, got, want)
+		}
+	}
+	if Locale("xx").IsRTL() {
+		t.Error(`"xx".IsRTL() = true, want false`)
+	}
+}
+
+func TestWrapBidi(t *testing.T) {
+	if got, want := wrapBidi(Arabic, "Sam"), "\u2068Sam\u2069"; got != want {
+		t.Errorf("wrapBidi(ar) = %q, want %q", got, want)
+	}
+	if got := wrapBidi(English, "Sam"); got != "Sam" {
+		t.Errorf("wrapBidi(en) = %q, want %q", got, "Sam")
+	}
+}
+
+func TestLocaleEntries(t *testing.T) {
+	for l, e := range builtinLocales {
+		if e.Word == "" {
+			t.Errorf("locale %q has no greeting word", l)
+		}
+		if n := strings.Count(e.Punct, "%s"); n != 3 {
+			t.Errorf("locale %q: Punct %q has %d placeholders, want 3", l, e.Punct, n)
+		}
+		if err := ValidateTemplate(e.template()); err != nil {
+			t.Errorf("locale %q: %v", l, err)
+		}
+		if prefix := compileTemplate(e.template(), e.Word).prefix; !strings.Contains(prefix, e.Word) {
+			t.Errorf("locale %q: template prefix %q does not contain word %q", l, prefix, e.Word)
+		}
+		if _, err := New(WithEmoji(e.Emoji)); err != nil {
+			t.Errorf("locale %q: default emoji: %v", l, err)
+		}
+	}
+}
+
+func TestDefaultEmoji(t *testing.T) {
+	want := map[Locale]string{
+		English:  "🐹",
+		French:   "👋",
+		Spanish:  "👋",
+		German:   "👋",
+		Japanese: "🙇",
+		Arabic:   "👋",
+		Hebrew:   "👋",
+		"pt":     DefaultEmoji,
+	}
+	for l, emoji := range want {
+		if got := l.DefaultEmoji(); got != emoji {
+			t.Errorf("Locale(%q).DefaultEmoji() = %q, want %q", l, got, emoji)
+		}
+	}
+	for _, l := range SupportedLocales() {
+		if _, ok := want[l]; !ok {
+			t.Errorf("no expected default emoji for locale %q", l)
+		}
+		if g := GreetDefault(); l == English && !strings.HasSuffix(g, English.DefaultEmoji()) {
+			t.Errorf("GreetDefault() = %q does not end with the English emoji", g)
+		}
+	}
+}
+
+func TestTemplateFrame(t *testing.T) {
+	for l, e := range builtinLocales {
+		tmpl := compileTemplate(e.template(), e.Word)
+		for _, word := range []string{"", "Hey"} {
+			for _, emoji := range []string{"", "🎉"} {
+				before, after := tmpl.frame(word, emoji)
+				if got, want := before+"Sam"+after, format(tmpl, word, "Sam", emoji); got != want {
+					t.Errorf("%s: frame(%q, %q) around Sam = %q, want %q", l, word, emoji, got, want)
+				}
+			}
+		}
+	}
+}
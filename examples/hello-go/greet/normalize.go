@@ -0,0 +1,37 @@
+package greet
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeName returns name in Unicode Normalization Form C, so that
+// canonically equivalent spellings, such as a precomposed "é" and an "e"
+// followed by a combining acute accent, become byte-identical. Invalid UTF-8
+// is replaced with U+FFFD first, so the result is always valid UTF-8.
+func NormalizeName(name string) string {
+	if !utf8.ValidString(name) {
+		name = strings.ToValidUTF8(name, string(utf8.RuneError))
+	}
+	return norm.NFC.String(name)
+}
+
+// TitleCaseName capitalizes the first grapheme cluster of name and leaves the
+// rest unchanged. Combining marks and multi-rune emoji in the first cluster
+// stay attached to it.
+func TitleCaseName(name string) string {
+	first, rest, _, _ := uniseg.FirstGraphemeClusterInString(name, -1)
+	if first == "" {
+		return name
+	}
+	r, size := utf8.DecodeRuneInString(first)
+	t := unicode.ToTitle(r)
+	if t == r {
+		return name
+	}
+	return norm.NFC.String(string(t)+first[size:]) + rest
+}
@@ -0,0 +1,78 @@
+package greet
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+const (
+	composedE   = "élodie"  // é as a single code point
+	decomposedE = "élodie" // e followed by a combining acute accent
+)
+
+func TestNormalizeName(t *testing.T) {
+	if composedE == decomposedE {
+		t.Fatal("test inputs are identical")
+	}
+	if a, b := NormalizeName(composedE), NormalizeName(decomposedE); a != b {
+		t.Errorf("NormalizeName(NFC) = %q, NormalizeName(NFD) = %q; want identical", a, b)
+	}
+	if got := NormalizeName(decomposedE); got != composedE {
+		t.Errorf("NormalizeName(%q) = %q, want %q", decomposedE, got, composedE)
+	}
+	if got, want := NormalizeName("Eve\xff"), "Eve\ufffd"; got != want {
+		t.Errorf("NormalizeName(invalid UTF-8) = %q, want %q", got, want)
+	}
+}
+
+func TestTitleCaseName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"élodie", "Élodie"},
+		{decomposedE, "Élodie"},
+		{"alice", "Alice"},
+		{"Alice", "Alice"},
+		{"ǆenan", "ǅenan"},
+		{"👩‍👩‍👧 family", "👩‍👩‍👧 family"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := TitleCaseName(tt.in); got != tt.want {
+			t.Errorf("TitleCaseName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestGreeterNormalize(t *testing.T) {
+	g, err := New(WithNormalize(true), WithLocale(French))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, b := g.Greet(composedE), g.Greet(decomposedE); a != b {
+		t.Errorf("Greet(NFC) = %q, Greet(NFD) = %q; want identical", a, b)
+	}
+	tc, err := New(WithTitleCase(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tc.Greet("élodie"), "Hello, Élodie! 🐹"; got != want {
+		t.Errorf("Greet = %q, want %q", got, want)
+	}
+}
+
+func FuzzNormalizeName(f *testing.F) {
+	for _, name := range append(weirdNames, composedE, decomposedE) {
+		f.Add(name)
+	}
+	f.Fuzz(func(t *testing.T, name string) {
+		once := NormalizeName(name)
+		if !utf8.ValidString(once) {
+			t.Fatalf("NormalizeName(%q) = %q, not valid UTF-8", name, once)
+		}
+		if twice := NormalizeName(once); twice != once {
+			t.Fatalf("NormalizeName is not idempotent for %q: %q, then %q", name, once, twice)
+		}
+	})
+}
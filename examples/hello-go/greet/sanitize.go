@@ -0,0 +1,87 @@
+package greet
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SanitizeName makes name safe to print to a terminal. Escape sequences
+// introduced by ESC are removed, whitespace control characters (tab, newline
+// and the like) become spaces, all other C0 and C1 control characters are
+// dropped and invalid UTF-8 is replaced by U+FFFD. Printable characters,
+// including accented letters and emoji, are kept as they are.
+func SanitizeName(name string) string {
+	if isPlainASCII(name) {
+		return name
+	}
+	var b strings.Builder
+	b.Grow(len(name))
+	for i := 0; i < len(name); {
+		r, size := utf8.DecodeRuneInString(name[i:])
+		switch {
+		case r == utf8.RuneError && size <= 1:
+			b.WriteRune(utf8.RuneError)
+		case r == '\x1b':
+			size = escapeLen(name[i:])
+		case r == '\t' || r == '\n' || r == '\v' || r == '\f' || r == '\r' || r == '\u0085':
+			b.WriteByte(' ')
+		case unicode.IsControl(r):
+		default:
+			b.WriteString(name[i : i+size])
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// isPlainASCII reports whether s consists of printable ASCII only and so
+// needs no sanitizing.
+func isPlainASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < 0x20 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// escapeLen returns the length of the escape sequence at the start of s,
+// which begins with ESC. Unterminated sequences extend to the end of s.
+func escapeLen(s string) int {
+	if len(s) < 2 {
+		return len(s)
+	}
+	switch s[1] {
+	case '[':
+		// CSI: parameter and intermediate bytes, then one final byte.
+		i := 2
+		for i < len(s) && s[i] >= 0x20 && s[i] <= 0x3f {
+			i++
+		}
+		if i < len(s) && s[i] >= 0x40 && s[i] <= 0x7e {
+			i++
+		}
+		return i
+	case ']', 'P', 'X', '^', '_':
+		// OSC, DCS, SOS, PM and APC: a string ended by BEL or ESC \.
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(s)
+	}
+	// Other sequences: intermediate bytes, then one final byte.
+	i := 1
+	for i < len(s) && s[i] >= 0x20 && s[i] <= 0x2f {
+		i++
+	}
+	if i < len(s) && s[i] >= 0x30 && s[i] <= 0x7e {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,86 @@
+package greet
+
+import (
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+func TestSanitizeName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "Alice", "Alice"},
+		{"accents and emoji", "Élodie 🎉 José", "Élodie 🎉 José"},
+		{"sgr color", "\x1b[31mEve\x1b[0m", "Eve"},
+		{"cursor movement", "Eve\x1b[2J\x1b[H", "Eve"},
+		{"osc title with bel", "\x1b]0;pwned\aEve", "Eve"},
+		{"osc title with st", "\x1b]0;pwned\x1b\\Eve", "Eve"},
+		{"charset designation", "\x1b(BEve", "Eve"},
+		{"unterminated csi", "Eve\x1b[31", "Eve"},
+		{"lone esc", "Eve\x1b", "Eve"},
+		{"newline and tab", "Mary\nJane\tDoe", "Mary Jane Doe"},
+		{"c0 controls", "E\x00v\x07e\x7f", "Eve"},
+		{"c1 controls", "E\u009bv\u0090e", "Eve"},
+		{"invalid utf-8", "Eve\xff", "Eve�"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeName(tt.in); got != tt.want {
+				t.Errorf("SanitizeName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGreeterSanitize(t *testing.T) {
+	name := "\x1b[31mEve\x1b[0m\n"
+	g, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := g.Greet(name), "Hello, Eve! 🐹"; got != want {
+		t.Errorf("Greet(%q) = %q, want %q", name, got, want)
+	}
+	raw, err := New(WithSanitize(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := raw.Greet(name), "Hello, \x1b[31mEve\x1b[0m! 🐹"; got != want {
+		t.Errorf("raw Greet(%q) = %q, want %q", name, got, want)
+	}
+}
+
+// weirdNames seed the fuzz corpora: encoded surrogates and other invalid
+// UTF-8, stacked combining marks and a string full of escape codes.
+var weirdNames = []string{
+	"",
+	"Alice",
+	"\xed\xa0\x80\xed\xbf\xbf",
+	"\ufffd\ufffd",
+	"\xff\xfe\xc0\x80",
+	"e\u0301\u0327\u0308",
+	"\u0301leading mark",
+	"Z\u0351\u0360\u0361\u035c\u0362",
+	"\x1b[31m\x1b]0;t\a\x1b[2J\x1bP1;2q\x1b\\\x1b(B\x1b[?25l\x1b",
+	"\u009b31m\u0090\u0085\x00\x7f",
+}
+
+func FuzzSanitizeName(f *testing.F) {
+	for _, name := range weirdNames {
+		f.Add(name)
+	}
+	f.Fuzz(func(t *testing.T, name string) {
+		got := SanitizeName(name)
+		if !utf8.ValidString(got) {
+			t.Fatalf("SanitizeName(%q) = %q, not valid UTF-8", name, got)
+		}
+		for _, r := range got {
+			if unicode.IsControl(r) {
+				t.Fatalf("SanitizeName(%q) = %q, contains control character %U", name, got, r)
+			}
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package greet
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+)
+
+// WrapGreeting wraps s onto lines of at most width terminal columns, breaking
+// only at spaces and never before a word made only of punctuation, such as
+// the "!" of the French greeting. Widths are measured per grapheme cluster,
+// so wide characters and emoji count as two columns and are never split, and
+// terminal escape sequences such as color codes take no width. A word wider
+// than width is put on a line of its own. A width of zero or less returns s
+// unchanged. WrapGreeting cannot tell the names in s apart from the rest and
+// may break a name at its spaces; WithWrap keeps names whole.
+func WrapGreeting(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	return wrapWords(strings.Split(s, " "), width)
+}
+
+// greetingWords splits a greeting into the words it may be wrapped between:
+// before and after, the text around the names, and the separators of the
+// list style between names break at their spaces, but the names do not.
+func greetingWords(before string, names []string, style listStyle, after string) []string {
+	var words []string
+	word := "" // the word being built, which continues until a space
+	add := func(text string) {
+		parts := strings.Split(text, " ")
+		word += parts[0]
+		for _, p := range parts[1:] {
+			words = append(words, word)
+			word = p
+		}
+	}
+	add(before)
+	for i, name := range names {
+		if i > 0 {
+			add(style.separator(i, len(names)))
+		}
+		word += name
+	}
+	add(after)
+	return append(words, word)
+}
+
+// wrapWords joins words with spaces, or line breaks where a line would grow
+// wider than width; see WrapGreeting.
+func wrapWords(words []string, width int) string {
+	// Punctuation-only words are glued to the word before them.
+	var glued []string
+	for i, word := range words {
+		if i > 0 && isPunct(word) {
+			glued[len(glued)-1] += " " + word
+			continue
+		}
+		glued = append(glued, word)
+	}
+
+	var b strings.Builder
+	lineWidth := 0
+	for i, word := range glued {
+		w := displayWidth(word)
+		switch {
+		case i == 0:
+		case lineWidth+1+w > width:
+			b.WriteByte('\n')
+			lineWidth = 0
+		default:
+			b.WriteByte(' ')
+			lineWidth++
+		}
+		b.WriteString(word)
+		lineWidth += w
+	}
+	return b.String()
+}
+
+// isPunct reports whether word is non-empty and made only of punctuation.
+func isPunct(word string) bool {
+	return word != "" && strings.TrimFunc(word, unicode.IsPunct) == ""
+}
+
+// displayWidth returns the number of terminal columns s occupies, ignoring
+// escape sequences.
+func displayWidth(s string) int {
+	width := 0
+	for s != "" {
+		if s[0] == '\x1b' {
+			s = s[escapeLen(s):]
+			continue
+		}
+		i := strings.IndexByte(s, '\x1b')
+		if i < 0 {
+			i = len(s)
+		}
+		width += uniseg.StringWidth(s[:i])
+		s = s[i:]
+	}
+	return width
+}
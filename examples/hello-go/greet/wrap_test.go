@@ -0,0 +1,90 @@
+package greet
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rivo/uniseg"
+)
+
+func TestWrapGreeting(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"Hello, Alice, Bob, and Carol! 🐹", 0, "Hello, Alice, Bob, and Carol! 🐹"},
+		{"Hello, Alice, Bob, and Carol! 🐹", 20, "Hello, Alice, Bob,\nand Carol! 🐹"},
+		{"Hello, Alice, Bob, and Carol! 🐹", 10, "Hello,\nAlice,\nBob, and\nCarol! 🐹"},
+		// The emoji is two columns wide, so it does not fit after "Carol!".
+		{"Carol! 🐹", 8, "Carol!\n🐹"},
+		{"Carol! 👩‍👩‍👧", 9, "Carol! 👩‍👩‍👧"},
+		{"Hello, Bartholomew!", 5, "Hello,\nBartholomew!"},
+		{"Bonjour, Camille ! 👋", 16, "Bonjour,\nCamille ! 👋"},
+		{"Bonjour, Camille ! 👋", 10, "Bonjour,\nCamille !\n👋"},
+		{"Hello, \x1b[1;96mSam\x1b[0m! 🐹", 14, "Hello, \x1b[1;96mSam\x1b[0m! 🐹"},
+	}
+	for _, tt := range tests {
+		if got := WrapGreeting(tt.s, tt.width); got != tt.want {
+			t.Errorf("WrapGreeting(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestWrapGreetingKeepsNamesAndEmoji(t *testing.T) {
+	groups := [][]string{
+		{"Alexandria", "Bartholomew", "Cassiopeia"},
+		{"Mary Ann Smith", "Bob"},
+		{"Ana", "Jean Paul", "Li Na Wu", "Bo"},
+	}
+	for _, names := range groups {
+		for _, emoji := range []string{"🐹", "👩‍👩‍👧", "🇫🇷"} {
+			g, err := New(WithEmoji(emoji), WithWrap(20))
+			if err != nil {
+				t.Fatal(err)
+			}
+			wrapped := g.Group(names).Greeting
+			lines := strings.Split(wrapped, "\n")
+			for _, line := range lines {
+				if w := uniseg.StringWidth(line); w > 20 {
+					t.Errorf("line %q is %d columns wide, want at most 20", line, w)
+				}
+			}
+			for _, want := range append(names, emoji) {
+				found := false
+				for _, line := range lines {
+					found = found || strings.Contains(line, want)
+				}
+				if !found {
+					t.Errorf("wrapped greeting %q split %q", wrapped, want)
+				}
+			}
+		}
+	}
+}
+
+func TestGreeterWrap(t *testing.T) {
+	tests := []struct {
+		opts  []Option
+		names []string
+		want  string
+	}{
+		{nil, []string{"Mary Ann Smith", "Bob"}, "Hello,\nMary Ann Smith and\nBob! 🐹"},
+		{nil, []string{"Alice", "Bob", "Carol"}, "Hello, Alice, Bob,\nand Carol! 🐹"},
+		{nil, []string{"Anna Maria Lopez"}, "Hello,\nAnna Maria Lopez! 🐹"},
+		{[]Option{WithLocale(French)}, []string{"Jean Paul", "Camille"}, "Bonjour, Jean Paul\net Camille ! 👋"},
+		{[]Option{WithTimeOfDay(func() time.Time { return time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC) })}, []string{"Mary Ann", "Bo"}, "Good morning,\nMary Ann and Bo!"},
+		// A name wider than the line stays whole on a line of its own.
+		{nil, []string{"Bartholomew Montgomery"}, "Hello,\nBartholomew Montgomery!\n🐹"},
+	}
+	for _, tt := range tests {
+		g, err := New(append(tt.opts, WithWrap(20))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := g.Group(tt.names).Greeting; got != tt.want {
+			t.Errorf("Group(%q) = %q, want %q", tt.names, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,6 @@
+// Package greetpb holds the Go code generated from greet.proto, the gRPC
+// API of hello-go serve. Regenerate it with go generate after editing the
+// proto file; this needs protoc, protoc-gen-go and protoc-gen-go-grpc.
+package greetpb
+
+//go:generate protoc -I.. --go_out=../.. --go_opt=module=github.com/while-basic/enact-template/examples/hello-go --go-grpc_out=../.. --go-grpc_opt=module=github.com/while-basic/enact-template/examples/hello-go greet.proto